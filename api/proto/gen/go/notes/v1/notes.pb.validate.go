@@ -408,6 +408,8 @@ func (m *GetNoteRequest) validate(all bool) error {
 
 	// no validation rules for Id
 
+	// no validation rules for OwnerId
+
 	if len(errors) > 0 {
 		return GetNoteRequestMultiError(errors)
 	}
@@ -887,6 +889,8 @@ func (m *UpdateNoteRequest) validate(all bool) error {
 
 	// no validation rules for Content
 
+	// no validation rules for Id
+
 	if len(errors) > 0 {
 		return UpdateNoteRequestMultiError(errors)
 	}
@@ -1122,6 +1126,8 @@ func (m *DeleteNoteRequest) validate(all bool) error {
 
 	// no validation rules for Id
 
+	// no validation rules for OwnerId
+
 	if len(errors) > 0 {
 		return DeleteNoteRequestMultiError(errors)
 	}
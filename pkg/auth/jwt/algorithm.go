@@ -0,0 +1,61 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Algorithm алгоритм подписи токенов
+type Algorithm string
+
+const (
+	// AlgorithmRS256 RSA PKCS#1 v1.5 с SHA-256 (по умолчанию)
+	AlgorithmRS256 Algorithm = "RS256"
+	// AlgorithmES256 ECDSA на кривой P-256 с SHA-256
+	AlgorithmES256 Algorithm = "ES256"
+	// AlgorithmEdDSA Ed25519: самые короткие подписи и быстрая проверка
+	AlgorithmEdDSA Algorithm = "EdDSA"
+)
+
+// signingMethod возвращает метод подписи golang-jwt для алгоритма
+func (a Algorithm) signingMethod() (jwt.SigningMethod, error) {
+	switch a {
+	case AlgorithmRS256:
+		return jwt.SigningMethodRS256, nil
+	case AlgorithmES256:
+		return jwt.SigningMethodES256, nil
+	case AlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSigningMethod, a)
+	}
+}
+
+// checkPublicKey проверяет, что тип ключа соответствует алгоритму
+func (a Algorithm) checkPublicKey(publicKey crypto.PublicKey) error {
+	switch a {
+	case AlgorithmRS256:
+		if _, ok := publicKey.(*rsa.PublicKey); ok {
+			return nil
+		}
+		return fmt.Errorf("%s requires RSA key, got %T", a, publicKey)
+	case AlgorithmES256:
+		if key, ok := publicKey.(*ecdsa.PublicKey); ok && key.Curve == elliptic.P256() {
+			return nil
+		}
+		return fmt.Errorf("%s requires ECDSA P-256 key, got %T", a, publicKey)
+	case AlgorithmEdDSA:
+		if _, ok := publicKey.(ed25519.PublicKey); ok {
+			return nil
+		}
+		return fmt.Errorf("%s requires Ed25519 key, got %T", a, publicKey)
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSigningMethod, a)
+	}
+}
@@ -8,5 +8,6 @@ var (
 	ErrExpiredToken         = errors.New("token expired")
 	ErrInvalidSigningMethod = errors.New("invalid signing method")
 	ErrMissingKey           = errors.New("missing key")
+	ErrUnknownKeyID         = errors.New("unknown key id")
 )
 
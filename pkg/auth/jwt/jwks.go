@@ -0,0 +1,60 @@
+package jwt
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// JWKSPath стандартный путь публикации JWKS
+const JWKSPath = "/.well-known/jwks.json"
+
+// JWK публичный ключ в формате RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// EC и OKP (Ed25519)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS набор публичных ключей в формате RFC 7517
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS возвращает публичные части всех активных ключей.
+// Сторонние сервисы могут проверять токены по этому документу,
+// не получая PEM файлы.
+func (m *Manager) JWKS() JWKS {
+	jwks := JWKS{Keys: make([]JWK, 0, len(m.keys))}
+	for _, k := range m.keys {
+		jwk := publicJWK(k.publicKey)
+		jwk.Use = "sig"
+		jwk.Alg = k.method.Alg()
+		jwk.Kid = k.id
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
+
+// JWKSHandler отдаёт JWKS документ; монтируется на JWKSPath
+func (m *Manager) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		// Клиенты кешируют документ; встретив незнакомый kid, им следует перечитать его
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		json.NewEncoder(w).Encode(m.JWKS())
+	})
+}
@@ -0,0 +1,86 @@
+package jwt
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestManager_JWKSHandler(t *testing.T) {
+	privateKey1, _ := generateTestKeys(t)
+	privateKey2, _ := generateTestKeys(t)
+	privateKey3, _ := generateTestKeys(t)
+
+	manager, err := NewManager(Config{
+		Keys: []KeyConfig{
+			{ID: "retired", PrivateKey: string(privateKeyToPEM(privateKey1)), Retired: true},
+			{ID: "previous", PublicKey: string(publicKeyToPEM(&privateKey2.PublicKey))},
+			{ID: "current", PrivateKey: string(privateKeyToPEM(privateKey3))},
+		},
+		Issuer: "test-issuer",
+	})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	manager.JWKSHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, JWKSPath, nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var jwks JWKS
+	if err := json.NewDecoder(rec.Body).Decode(&jwks); err != nil {
+		t.Fatalf("failed to decode JWKS: %v", err)
+	}
+
+	if len(jwks.Keys) != 2 {
+		t.Fatalf("JWKS keys = %d, want 2", len(jwks.Keys))
+	}
+	for i, kid := range []string{"previous", "current"} {
+		key := jwks.Keys[i]
+		if key.Kid != kid || key.Kty != "RSA" || key.Alg != "RS256" || key.N == "" || key.E != "AQAB" {
+			t.Errorf("JWKS key %d = %+v, want RSA key %q", i, key, kid)
+		}
+	}
+}
+
+func TestThumbprint(t *testing.T) {
+	// Пример из RFC 7638, раздел 3.1
+	const n = "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+
+	manager, err := NewVerifier(Config{
+		Keys: []KeyConfig{{PublicKey: jwkToPEM(t, n, "AQAB")}},
+	})
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+
+	if got := manager.JWKS().Keys[0].Kid; got != "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs" {
+		t.Errorf("kid = %s, want RFC 7638 thumbprint", got)
+	}
+}
+
+// jwkToPEM собирает PEM публичного RSA ключа из параметров JWK
+func jwkToPEM(t *testing.T, n, e string) string {
+	t.Helper()
+
+	nBytes, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		t.Fatalf("failed to decode n: %v", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil {
+		t.Fatalf("failed to decode e: %v", err)
+	}
+
+	return string(publicKeyToPEM(&rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(new(big.Int).SetBytes(eBytes).Int64()),
+	}))
+}
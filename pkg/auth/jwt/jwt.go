@@ -1,6 +1,9 @@
 package jwt
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -10,20 +13,40 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 // Claims представляет claims JWT токена
 type Claims struct {
-	UserID string `json:"user_id"`
+	UserID string   `json:"user_id"`
+	Roles  []string `json:"roles,omitempty"`
+	Scopes []string `json:"scopes,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// Manager управляет JWT токенами с использованием RS256
+// HasRole проверяет, есть ли у пользователя роль
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Manager управляет JWT токенами. Алгоритм подписи задаётся в Config
+// (RS256 по умолчанию, ES256 или EdDSA).
+// Manager работает с набором ключей: каждый токен получает заголовок kid,
+// подписывает самый новый ключ, а проверяют все не выведенные из оборота.
+// Это позволяет ротировать ключи, не инвалидируя выпущенные токены.
 type Manager struct {
-	privateKey *rsa.PrivateKey
-	publicKey  *rsa.PublicKey
-	issuer     string
-	ttl        time.Duration
+	// signingKey последний активный ключ с приватной частью; nil у verifier
+	signingKey *key
+	// keys активные ключи проверки в порядке от старых к новым
+	keys   []*key
+	keyIDs map[string]*key
+	issuer string
+	ttl    time.Duration
 }
 
 // Config конфигурация для JWT Manager
@@ -36,12 +59,35 @@ type Config struct {
 	PublicKeyPath string
 	// PublicKey содержимое публичного ключа в PEM формате (приоритет над PublicKeyPath)
 	PublicKey string
+	// Algorithm алгоритм подписи (по умолчанию RS256). Тип ключей
+	// должен ему соответствовать.
+	Algorithm Algorithm
+	// Keys набор ключей в порядке от старых к новым. Если задан,
+	// одиночные поля PrivateKey*/PublicKey* игнорируются.
+	Keys []KeyConfig
 	// Issuer issuer токена (обычно название сервиса)
 	Issuer string
 	// TTL время жизни токена
 	TTL time.Duration
 }
 
+// keyConfigs возвращает набор ключей, собирая его из одиночных полей,
+// если Keys не задан
+func (cfg Config) keyConfigs() []KeyConfig {
+	if len(cfg.Keys) > 0 {
+		return cfg.Keys
+	}
+	if cfg.PrivateKey == "" && cfg.PrivateKeyPath == "" && cfg.PublicKey == "" && cfg.PublicKeyPath == "" {
+		return nil
+	}
+	return []KeyConfig{{
+		PrivateKey:     cfg.PrivateKey,
+		PrivateKeyPath: cfg.PrivateKeyPath,
+		PublicKey:      cfg.PublicKey,
+		PublicKeyPath:  cfg.PublicKeyPath,
+	}}
+}
+
 // NewManager создаёт новый JWT Manager
 func NewManager(cfg Config) (*Manager, error) {
 	if cfg.Issuer == "" {
@@ -51,42 +97,96 @@ func NewManager(cfg Config) (*Manager, error) {
 		cfg.TTL = 24 * time.Hour // По умолчанию 24 часа
 	}
 
-	// Загрузка приватного ключа
-	var privateKey *rsa.PrivateKey
-	var err error
-
-	if cfg.PrivateKey != "" {
-		privateKey, err = parsePrivateKey([]byte(cfg.PrivateKey))
-	} else if cfg.PrivateKeyPath != "" {
-		privateKey, err = loadPrivateKeyFromFile(cfg.PrivateKeyPath)
-	} else {
+	m, err := newManager(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if m.signingKey == nil {
 		return nil, fmt.Errorf("%w: private key is required", ErrMissingKey)
 	}
+
+	return m, nil
+}
+
+// NewVerifier создаёт Manager, который только проверяет токены.
+// Нужен лишь публичный ключ, поэтому приватный ключ не приходится
+// раздавать сервисам, которые токены не выпускают. Sign у такого
+// Manager возвращает ErrMissingKey.
+func NewVerifier(cfg Config) (*Manager, error) {
+	if cfg.Issuer == "" {
+		cfg.Issuer = "auth-service"
+	}
+
+	m, err := newManager(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load private key: %w", err)
+		return nil, err
 	}
+	if len(m.keys) == 0 {
+		return nil, fmt.Errorf("%w: public key is required", ErrMissingKey)
+	}
+
+	// Приватные ключи verifier не нужны
+	m.signingKey = nil
+	for _, k := range m.keys {
+		k.privateKey = nil
+	}
+
+	return m, nil
+}
 
-	// Загрузка публичного ключа
-	var publicKey *rsa.PublicKey
+// newManager загружает набор ключей и выбирает ключ подписи
+func newManager(cfg Config) (*Manager, error) {
+	if cfg.Algorithm == "" {
+		cfg.Algorithm = AlgorithmRS256
+	}
 
-	if cfg.PublicKey != "" {
-		publicKey, err = parsePublicKey([]byte(cfg.PublicKey))
-	} else if cfg.PublicKeyPath != "" {
-		publicKey, err = loadPublicKeyFromFile(cfg.PublicKeyPath)
-	} else {
-		// Если публичный ключ не указан, извлекаем его из приватного
-		publicKey = &privateKey.PublicKey
+	m := &Manager{
+		keyIDs: make(map[string]*key),
+		issuer: cfg.Issuer,
+		ttl:    cfg.TTL,
 	}
+
+	for _, kc := range cfg.keyConfigs() {
+		if kc.Retired {
+			continue
+		}
+
+		if kc.Algorithm == "" {
+			kc.Algorithm = cfg.Algorithm
+		}
+
+		k, err := loadKey(kc)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := m.keyIDs[k.id]; ok {
+			return nil, fmt.Errorf("duplicate key id %q", k.id)
+		}
+
+		m.keys = append(m.keys, k)
+		m.keyIDs[k.id] = k
+		if k.privateKey != nil {
+			m.signingKey = k
+		}
+	}
+
+	return m, nil
+}
+
+// NewVerifierFromEnv создаёт verify-only Manager из переменных окружения
+func NewVerifierFromEnv() (*Manager, error) {
+	keys, err := keysFromEnv()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load public key: %w", err)
+		return nil, err
 	}
 
-	return &Manager{
-		privateKey: privateKey,
-		publicKey:  publicKey,
-		issuer:     cfg.Issuer,
-		ttl:        cfg.TTL,
-	}, nil
+	return NewVerifier(Config{
+		PublicKey:     os.Getenv("JWT_RSA_PUBLIC_KEY"),
+		PublicKeyPath: os.Getenv("JWT_RSA_PUBLIC_KEY_PATH"),
+		Algorithm:     Algorithm(os.Getenv("JWT_ALGORITHM")),
+		Keys:          keys,
+		Issuer:        os.Getenv("JWT_ISSUER"),
+	})
 }
 
 // NewManagerFromEnv создаёт JWT Manager из переменных окружения
@@ -107,42 +207,67 @@ func NewManagerFromEnv() (*Manager, error) {
 		}
 	}
 
+	keys, err := keysFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
 	return NewManager(Config{
 		PrivateKey:     privateKey,
 		PublicKey:      publicKey,
 		PrivateKeyPath: privateKeyPath,
 		PublicKeyPath:  publicKeyPath,
+		Algorithm:      Algorithm(os.Getenv("JWT_ALGORITHM")),
+		Keys:           keys,
 		Issuer:         issuer,
 		TTL:            ttl,
 	})
 }
 
-// Sign создаёт новый JWT токен для пользователя
-func (m *Manager) Sign(userID string) (string, error) {
+// Sign создаёт новый JWT токен для пользователя.
+// Роли, scopes и audience добавляются через опции.
+func (m *Manager) Sign(userID string, opts ...SignOption) (string, error) {
+	if m.signingKey == nil {
+		return "", fmt.Errorf("%w: private key is required for signing", ErrMissingKey)
+	}
+
 	now := time.Now()
 	claims := Claims{
 		UserID: userID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			// jti позволяет отозвать конкретный токен до истечения срока
+			ID:        uuid.NewString(),
 			Issuer:    m.issuer,
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(m.ttl)),
 		},
 	}
+	for _, opt := range opts {
+		opt(&claims)
+	}
+
+	token := jwt.NewWithClaims(m.signingKey.method, claims)
+	token.Header["kid"] = m.signingKey.id
+	return token.SignedString(m.signingKey.privateKey)
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-	return token.SignedString(m.privateKey)
+// TTL возвращает время жизни выпускаемых токенов
+func (m *Manager) TTL() time.Duration {
+	return m.ttl
 }
 
-// Validate проверяет и парсит JWT токен
-func (m *Manager) Validate(tokenString string) (*Claims, error) {
+// Validate проверяет и парсит JWT токен.
+// Опции позволяют потребовать audience и допустить расхождение часов.
+func (m *Manager) Validate(tokenString string, opts ...ValidateOption) (*Claims, error) {
+	var o validateOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// Парсинг токена
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		// Проверка алгоритма подписи
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			return nil, fmt.Errorf("%w: expected RS256, got %v", ErrInvalidSigningMethod, token.Header["alg"])
-		}
-		return m.publicKey, nil
-	})
+		return m.verificationKey(token)
+	}, o.parserOptions()...)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -170,19 +295,50 @@ func (m *Manager) Validate(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
-// parsePrivateKey парсит приватный RSA ключ из PEM формата
-func parsePrivateKey(keyData []byte) (*rsa.PrivateKey, error) {
+// verificationKey выбирает ключ проверки по заголовку kid.
+// Алгоритм токена обязан совпадать с алгоритмом ключа: иначе токен,
+// подписанный, например, HS256 публичным ключом, мог бы пройти проверку.
+func (m *Manager) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		// Токены, выпущенные до появления kid, проверяем всеми активными ключами того же алгоритма
+		set := jwt.VerificationKeySet{}
+		for _, k := range m.keys {
+			if k.method.Alg() == token.Method.Alg() {
+				set.Keys = append(set.Keys, k.publicKey)
+			}
+		}
+		if len(set.Keys) == 0 {
+			return nil, fmt.Errorf("%w: unexpected algorithm %v", ErrInvalidSigningMethod, token.Header["alg"])
+		}
+		return set, nil
+	}
+
+	k, ok := m.keyIDs[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, kid)
+	}
+	if k.method.Alg() != token.Method.Alg() {
+		return nil, fmt.Errorf("%w: expected %s, got %v", ErrInvalidSigningMethod, k.method.Alg(), token.Header["alg"])
+	}
+	return k.publicKey, nil
+}
+
+// parsePrivateKey парсит приватный ключ из PEM формата.
+// Поддерживаются RSA (PKCS1, PKCS8), ECDSA (SEC1, PKCS8) и Ed25519 (PKCS8).
+func parsePrivateKey(keyData []byte) (crypto.Signer, error) {
 	block, _ := pem.Decode(keyData)
 	if block == nil {
 		return nil, errors.New("failed to decode PEM block")
 	}
 
-	var key *rsa.PrivateKey
-	var err error
-
 	// Попытка парсинга PKCS1
-	key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
-	if err == nil {
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	// Попытка парсинга SEC1 (EC PRIVATE KEY)
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
 		return key, nil
 	}
 
@@ -192,16 +348,21 @@ func parsePrivateKey(keyData []byte) (*rsa.PrivateKey, error) {
 		return nil, fmt.Errorf("failed to parse private key: %w", err)
 	}
 
-	key, ok := pkcs8Key.(*rsa.PrivateKey)
-	if !ok {
-		return nil, errors.New("key is not RSA private key")
+	switch key := pkcs8Key.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case *ecdsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", pkcs8Key)
 	}
-
-	return key, nil
 }
 
-// parsePublicKey парсит публичный RSA ключ из PEM формата
-func parsePublicKey(keyData []byte) (*rsa.PublicKey, error) {
+// parsePublicKey парсит публичный ключ из PEM формата.
+// Поддерживаются RSA (PKIX, PKCS1), ECDSA (PKIX) и Ed25519 (PKIX).
+func parsePublicKey(keyData []byte) (crypto.PublicKey, error) {
 	block, _ := pem.Decode(keyData)
 	if block == nil {
 		return nil, errors.New("failed to decode PEM block")
@@ -220,16 +381,16 @@ func parsePublicKey(keyData []byte) (*rsa.PublicKey, error) {
 		}
 	}
 
-	rsaKey, ok := key.(*rsa.PublicKey)
-	if !ok {
-		return nil, errors.New("key is not RSA public key")
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
 	}
-
-	return rsaKey, nil
 }
 
 // loadPrivateKeyFromFile загружает приватный ключ из файла
-func loadPrivateKeyFromFile(path string) (*rsa.PrivateKey, error) {
+func loadPrivateKeyFromFile(path string) (crypto.Signer, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read private key file: %w", err)
@@ -238,11 +399,10 @@ func loadPrivateKeyFromFile(path string) (*rsa.PrivateKey, error) {
 }
 
 // loadPublicKeyFromFile загружает публичный ключ из файла
-func loadPublicKeyFromFile(path string) (*rsa.PublicKey, error) {
+func loadPublicKeyFromFile(path string) (crypto.PublicKey, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read public key file: %w", err)
 	}
 	return parsePublicKey(data)
 }
-
@@ -1,6 +1,10 @@
 package jwt
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -9,6 +13,8 @@ import (
 	"os"
 	"testing"
 	"time"
+
+	"github.com/golang-jwt/jwt/v5"
 )
 
 // generateTestKeys создаёт тестовую пару RSA ключей
@@ -151,6 +157,94 @@ func TestManager_Sign(t *testing.T) {
 	if claims.Issuer != "test-issuer" {
 		t.Errorf("Validate() Issuer = %v, want test-issuer", claims.Issuer)
 	}
+	if claims.ID == "" {
+		t.Error("Validate() ID (jti) is empty")
+	}
+
+	// Каждый токен получает собственный jti
+	other, err := manager.Sign("user-123")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	otherClaims, err := manager.Validate(other)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if otherClaims.ID == claims.ID {
+		t.Error("Sign() issued two tokens with the same jti")
+	}
+}
+
+func TestManager_CustomClaims(t *testing.T) {
+	privateKey, _ := generateTestKeys(t)
+
+	manager, err := NewManager(Config{
+		PrivateKey: string(privateKeyToPEM(privateKey)),
+		Issuer:     "test-issuer",
+		TTL:        time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	token, err := manager.Sign("user-123",
+		WithRoles("admin", "editor"),
+		WithScopes("notes:read"),
+		WithAudience("rest-api"),
+	)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	claims, err := manager.Validate(token, WithRequiredAudience("rest-api"))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if !claims.HasRole("admin") || !claims.HasRole("editor") || claims.HasRole("owner") {
+		t.Errorf("Validate() Roles = %v, want [admin editor]", claims.Roles)
+	}
+	if len(claims.Scopes) != 1 || claims.Scopes[0] != "notes:read" {
+		t.Errorf("Validate() Scopes = %v, want [notes:read]", claims.Scopes)
+	}
+
+	if _, err := manager.Validate(token, WithRequiredAudience("billing")); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Validate() wrong audience error = %v, want ErrInvalidToken", err)
+	}
+
+	// Токен без aud не проходит, если audience обязателен
+	plain, err := manager.Sign("user-123")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if _, err := manager.Validate(plain, WithRequiredAudience("rest-api")); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Validate() missing audience error = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestManager_Validate_Leeway(t *testing.T) {
+	privateKey, _ := generateTestKeys(t)
+
+	// Токен истёк 10 секунд назад
+	manager, err := NewManager(Config{
+		PrivateKey: string(privateKeyToPEM(privateKey)),
+		Issuer:     "test-issuer",
+		TTL:        -10 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	token, err := manager.Sign("user-123")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	if _, err := manager.Validate(token); err != ErrExpiredToken {
+		t.Errorf("Validate() error = %v, want %v", err, ErrExpiredToken)
+	}
+	if _, err := manager.Validate(token, WithLeeway(time.Minute)); err != nil {
+		t.Errorf("Validate() with leeway error = %v", err)
+	}
 }
 
 func TestManager_Validate_InvalidToken(t *testing.T) {
@@ -338,6 +432,258 @@ func TestManager_Validate_WrongKey(t *testing.T) {
 	}
 }
 
+func TestNewVerifier(t *testing.T) {
+	privateKey, publicKey := generateTestKeys(t)
+
+	signer, err := NewManager(Config{
+		PrivateKey: string(privateKeyToPEM(privateKey)),
+		Issuer:     "test-issuer",
+		TTL:        time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	verifier, err := NewVerifier(Config{
+		PublicKey: string(publicKeyToPEM(publicKey)),
+		Issuer:    "test-issuer",
+	})
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+
+	token, err := signer.Sign("user-123")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	claims, err := verifier.Validate(token)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if claims.UserID != "user-123" {
+		t.Errorf("Validate() UserID = %v, want user-123", claims.UserID)
+	}
+
+	// Verifier не умеет подписывать
+	if _, err := verifier.Sign("user-123"); !errors.Is(err, ErrMissingKey) {
+		t.Errorf("Sign() error = %v, want ErrMissingKey", err)
+	}
+}
+
+func TestNewVerifier_MissingKey(t *testing.T) {
+	_, err := NewVerifier(Config{Issuer: "test-issuer"})
+	if !errors.Is(err, ErrMissingKey) {
+		t.Errorf("NewVerifier() error = %v, want ErrMissingKey", err)
+	}
+}
+
+// pkcs8ToPEM конвертирует приватный ключ любого типа в PKCS8 PEM
+func pkcs8ToPEM(t *testing.T, key crypto.PrivateKey) string {
+	t.Helper()
+
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal private key: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}))
+}
+
+// pkixToPEM конвертирует публичный ключ любого типа в PKIX PEM
+func pkixToPEM(t *testing.T, key crypto.PublicKey) string {
+	t.Helper()
+
+	keyBytes, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: keyBytes}))
+}
+
+func TestManager_Algorithms(t *testing.T) {
+	rsaKey, _ := generateTestKeys(t)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ECDSA key: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate Ed25519 key: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		algorithm Algorithm
+		key       crypto.Signer
+	}{
+		{name: "default RS256", key: rsaKey},
+		{name: "ES256", algorithm: AlgorithmES256, key: ecKey},
+		{name: "EdDSA", algorithm: AlgorithmEdDSA, key: edKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := NewManager(Config{
+				Algorithm:  tt.algorithm,
+				PrivateKey: pkcs8ToPEM(t, tt.key),
+				Issuer:     "test-issuer",
+			})
+			if err != nil {
+				t.Fatalf("NewManager() error = %v", err)
+			}
+			verifier, err := NewVerifier(Config{
+				Algorithm: tt.algorithm,
+				PublicKey: pkixToPEM(t, tt.key.Public()),
+				Issuer:    "test-issuer",
+			})
+			if err != nil {
+				t.Fatalf("NewVerifier() error = %v", err)
+			}
+
+			token, err := signer.Sign("user-123")
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+			claims, err := verifier.Validate(token)
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if claims.UserID != "user-123" {
+				t.Errorf("Validate() UserID = %v, want user-123", claims.UserID)
+			}
+
+			wantAlg := string(tt.algorithm)
+			if wantAlg == "" {
+				wantAlg = string(AlgorithmRS256)
+			}
+			if got := verifier.JWKS().Keys[0].Alg; got != wantAlg {
+				t.Errorf("JWKS alg = %s, want %s", got, wantAlg)
+			}
+		})
+	}
+}
+
+func TestManager_AlgorithmMismatch(t *testing.T) {
+	rsaKey, _ := generateTestKeys(t)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate Ed25519 key: %v", err)
+	}
+
+	// Тип ключа должен соответствовать алгоритму
+	if _, err := NewManager(Config{PrivateKey: pkcs8ToPEM(t, edKey)}); err == nil {
+		t.Error("NewManager() with Ed25519 key and RS256 expected error")
+	}
+	if _, err := NewManager(Config{Algorithm: AlgorithmES256, PrivateKey: pkcs8ToPEM(t, rsaKey)}); err == nil {
+		t.Error("NewManager() with RSA key and ES256 expected error")
+	}
+	if _, err := NewManager(Config{Algorithm: "HS256", PrivateKey: pkcs8ToPEM(t, rsaKey)}); !errors.Is(err, ErrInvalidSigningMethod) {
+		t.Errorf("NewManager() with HS256 error = %v, want ErrInvalidSigningMethod", err)
+	}
+
+	// Токен другого алгоритма отклоняется, даже если kid совпадает
+	edManager, err := NewManager(Config{
+		Algorithm: AlgorithmEdDSA,
+		Keys:      []KeyConfig{{ID: "shared", PrivateKey: pkcs8ToPEM(t, edKey)}},
+		Issuer:    "test-issuer",
+	})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	rsaManager, err := NewManager(Config{
+		Keys:   []KeyConfig{{ID: "shared", PrivateKey: pkcs8ToPEM(t, rsaKey)}},
+		Issuer: "test-issuer",
+	})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	token, err := edManager.Sign("user-123")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if _, err := rsaManager.Validate(token); !errors.Is(err, ErrInvalidSigningMethod) {
+		t.Errorf("Validate() error = %v, want ErrInvalidSigningMethod", err)
+	}
+}
+
+func TestManager_KeyRotation(t *testing.T) {
+	oldPrivateKey, _ := generateTestKeys(t)
+	newPrivateKey, _ := generateTestKeys(t)
+
+	oldKey := KeyConfig{ID: "2024-01", PrivateKey: string(privateKeyToPEM(oldPrivateKey))}
+	newKey := KeyConfig{ID: "2024-06", PrivateKey: string(privateKeyToPEM(newPrivateKey))}
+
+	before, err := NewManager(Config{Keys: []KeyConfig{oldKey}, Issuer: "test-issuer"})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	oldToken, err := before.Sign("user-123")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	// После ротации подписывает новый ключ, старые токены остаются валидными
+	after, err := NewManager(Config{Keys: []KeyConfig{oldKey, newKey}, Issuer: "test-issuer"})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	if _, err := after.Validate(oldToken); err != nil {
+		t.Errorf("Validate() old token error = %v", err)
+	}
+
+	newToken, err := after.Sign("user-123")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if _, err := before.Validate(newToken); !errors.Is(err, ErrUnknownKeyID) {
+		t.Errorf("Validate() with old key set error = %v, want ErrUnknownKeyID", err)
+	}
+
+	// Выведенный из оборота ключ больше не проверяет токены
+	oldKey.Retired = true
+	retired, err := NewManager(Config{Keys: []KeyConfig{oldKey, newKey}, Issuer: "test-issuer"})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	if _, err := retired.Validate(oldToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Validate() retired key error = %v, want ErrInvalidToken", err)
+	}
+	if _, err := retired.Validate(newToken); err != nil {
+		t.Errorf("Validate() new token error = %v", err)
+	}
+}
+
+func TestManager_Validate_WithoutKeyID(t *testing.T) {
+	privateKey, _ := generateTestKeys(t)
+
+	manager, err := NewManager(Config{
+		PrivateKey: string(privateKeyToPEM(privateKey)),
+		Issuer:     "test-issuer",
+		TTL:        time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	// Токен, выпущенный до появления kid
+	claims := Claims{
+		UserID: "user-123",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "test-issuer",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(privateKey)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+
+	if _, err := manager.Validate(token); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
 func TestNewManagerFromEnv(t *testing.T) {
 	privateKey, publicKey := generateTestKeys(t)
 	privateKeyPEM := privateKeyToPEM(privateKey)
@@ -0,0 +1,206 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeyConfig описывает один ключ из набора
+type KeyConfig struct {
+	// Algorithm алгоритм ключа; если пуст, используется Config.Algorithm.
+	// Позволяет сменить алгоритм через обычную ротацию ключей.
+	Algorithm Algorithm
+	// ID идентификатор ключа (kid). Если пуст, используется RFC 7638
+	// thumbprint публичного ключа.
+	ID string
+	// PrivateKeyPath путь к файлу с приватным ключом
+	PrivateKeyPath string
+	// PrivateKey содержимое приватного ключа в PEM формате (приоритет над PrivateKeyPath)
+	PrivateKey string
+	// PublicKeyPath путь к файлу с публичным ключом
+	PublicKeyPath string
+	// PublicKey содержимое публичного ключа в PEM формате (приоритет над PublicKeyPath).
+	// Если не указан, извлекается из приватного.
+	PublicKey string
+	// Retired ключ выведен из оборота: не подписывает, не проверяет
+	// и не публикуется в JWKS
+	Retired bool
+}
+
+// key загруженный ключ набора
+type key struct {
+	id         string
+	method     jwt.SigningMethod
+	privateKey crypto.Signer
+	publicKey  crypto.PublicKey
+}
+
+// loadKey загружает ключ по конфигурации
+func loadKey(kc KeyConfig) (*key, error) {
+	method, err := kc.Algorithm.signingMethod()
+	if err != nil {
+		return nil, err
+	}
+
+	var privateKey crypto.Signer
+
+	if kc.PrivateKey != "" {
+		privateKey, err = parsePrivateKey([]byte(kc.PrivateKey))
+	} else if kc.PrivateKeyPath != "" {
+		privateKey, err = loadPrivateKeyFromFile(kc.PrivateKeyPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load private key: %w", err)
+	}
+
+	var publicKey crypto.PublicKey
+
+	if kc.PublicKey != "" {
+		publicKey, err = parsePublicKey([]byte(kc.PublicKey))
+	} else if kc.PublicKeyPath != "" {
+		publicKey, err = loadPublicKeyFromFile(kc.PublicKeyPath)
+	} else if privateKey != nil {
+		publicKey = privateKey.Public()
+	} else {
+		return nil, fmt.Errorf("%w: public or private key is required", ErrMissingKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load public key: %w", err)
+	}
+
+	if err := kc.Algorithm.checkPublicKey(publicKey); err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	if privateKey != nil {
+		if err := kc.Algorithm.checkPublicKey(privateKey.Public()); err != nil {
+			return nil, fmt.Errorf("invalid private key: %w", err)
+		}
+	}
+
+	id := kc.ID
+	if id == "" {
+		id = thumbprint(publicKey)
+	}
+
+	return &key{
+		id:         id,
+		method:     method,
+		privateKey: privateKey,
+		publicKey:  publicKey,
+	}, nil
+}
+
+// thumbprint вычисляет RFC 7638 JWK thumbprint публичного ключа
+func thumbprint(publicKey crypto.PublicKey) string {
+	jwk := publicJWK(publicKey)
+
+	// RFC 7638 требует только обязательные поля в лексикографическом порядке;
+	// json.Marshal сортирует ключи map
+	members := map[string]string{"kty": jwk.Kty}
+	switch jwk.Kty {
+	case "RSA":
+		members["e"] = jwk.E
+		members["n"] = jwk.N
+	case "EC":
+		members["crv"] = jwk.Crv
+		members["x"] = jwk.X
+		members["y"] = jwk.Y
+	case "OKP":
+		members["crv"] = jwk.Crv
+		members["x"] = jwk.X
+	}
+
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// publicJWK возвращает параметры публичного ключа в формате JWK (без kid, alg, use)
+func publicJWK(publicKey crypto.PublicKey) JWK {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		return JWK{
+			Kty: "EC",
+			Crv: key.Curve.Params().Name,
+			X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, size))),
+			Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, size))),
+		}
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+		}
+	default:
+		return JWK{}
+	}
+}
+
+// keysFromEnv читает набор ключей из каталога JWT_KEYS_DIR.
+// Каждый файл <kid>.pem содержит приватный или публичный ключ;
+// ключи упорядочиваются по имени файла, поэтому kid удобно называть
+// по дате выпуска (например, 2024-06-01.pem). Подписывает последний
+// приватный ключ. JWT_RETIRED_KEY_IDS перечисляет через запятую kid,
+// выведенные из оборота.
+func keysFromEnv() ([]KeyConfig, error) {
+	dir := os.Getenv("JWT_KEYS_DIR")
+	if dir == "" {
+		return nil, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT_KEYS_DIR: %w", err)
+	}
+	sort.Strings(paths)
+
+	retired := make(map[string]bool)
+	for _, id := range strings.Split(os.Getenv("JWT_RETIRED_KEY_IDS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			retired[id] = true
+		}
+	}
+
+	keys := make([]KeyConfig, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file: %w", err)
+		}
+
+		kc := KeyConfig{ID: strings.TrimSuffix(filepath.Base(path), ".pem")}
+		kc.Retired = retired[kc.ID]
+		if strings.Contains(string(data), "PRIVATE KEY") {
+			kc.PrivateKey = string(data)
+		} else {
+			kc.PublicKey = string(data)
+		}
+		keys = append(keys, kc)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no *.pem files in %s", ErrMissingKey, dir)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,65 @@
+package jwt
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SignOption добавляет claims к выпускаемому токену
+type SignOption func(*Claims)
+
+// WithRoles добавляет роли пользователя
+func WithRoles(roles ...string) SignOption {
+	return func(c *Claims) {
+		c.Roles = append(c.Roles, roles...)
+	}
+}
+
+// WithScopes добавляет scopes (разрешения) токена
+func WithScopes(scopes ...string) SignOption {
+	return func(c *Claims) {
+		c.Scopes = append(c.Scopes, scopes...)
+	}
+}
+
+// WithAudience задаёт получателей токена (claim aud)
+func WithAudience(audience ...string) SignOption {
+	return func(c *Claims) {
+		c.Audience = append(c.Audience, audience...)
+	}
+}
+
+// ValidateOption настраивает проверку токена
+type ValidateOption func(*validateOptions)
+
+type validateOptions struct {
+	audience string
+	leeway   time.Duration
+}
+
+// WithRequiredAudience требует, чтобы aud токена содержал audience
+func WithRequiredAudience(audience string) ValidateOption {
+	return func(o *validateOptions) {
+		o.audience = audience
+	}
+}
+
+// WithLeeway допускает расхождение часов при проверке exp, nbf и iat
+func WithLeeway(leeway time.Duration) ValidateOption {
+	return func(o *validateOptions) {
+		o.leeway = leeway
+	}
+}
+
+// parserOptions переводит опции проверки в опции парсера golang-jwt
+func (o validateOptions) parserOptions() []jwt.ParserOption {
+	var opts []jwt.ParserOption
+	if o.audience != "" {
+		opts = append(opts, jwt.WithAudience(o.audience))
+	}
+	if o.leeway > 0 {
+		opts = append(opts, jwt.WithLeeway(o.leeway))
+	}
+	return opts
+}
@@ -0,0 +1,56 @@
+// Package clientip передаёт IP адрес клиента от gateway во внутренние gRPC сервисы
+package clientip
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// MetadataKey ключ gRPC metadata с IP адресом клиента
+	MetadataKey = "x-client-ip"
+	// TokenMetadataKey ключ gRPC metadata с общим секретом gateway, подтверждающим MetadataKey
+	TokenMetadataKey = "x-client-ip-token"
+)
+
+// NewOutgoingContext добавляет IP клиента и секрет gateway в metadata исходящего gRPC запроса.
+// addr может быть как IP, так и host:port (как в http.Request.RemoteAddr).
+// Без token IP не передаётся: сервис всё равно ему не поверит.
+func NewOutgoingContext(ctx context.Context, addr, token string) context.Context {
+	ip := hostOnly(addr)
+	if ip == "" || token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, ip, TokenMetadataKey, token)
+}
+
+// FromIncomingContext возвращает IP клиента из metadata входящего gRPC запроса.
+// Metadata учитывается, только если в ней передан совпадающий token: иначе любой,
+// кто достучится до gRPC порта, подставлял бы чужой IP и обходил ограничения по IP.
+// В остальных случаях используется адрес соединения; пустой token - всегда он.
+func FromIncomingContext(ctx context.Context, token string) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && token != "" {
+		values := md.Get(MetadataKey)
+		tokens := md.Get(TokenMetadataKey)
+		if len(values) > 0 && values[0] != "" && len(tokens) > 0 &&
+			subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(token)) == 1 {
+			return values[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return hostOnly(p.Addr.String())
+	}
+	return ""
+}
+
+// hostOnly отбрасывает порт из host:port
+func hostOnly(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
@@ -0,0 +1,73 @@
+package clientip
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const testToken = "gateway-secret"
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		addr string
+		want string
+	}{
+		{name: "ip", addr: "203.0.113.7", want: "203.0.113.7"},
+		{name: "host and port", addr: "203.0.113.7:54321", want: "203.0.113.7"},
+		{name: "ipv6 with port", addr: "[2001:db8::1]:443", want: "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := NewOutgoingContext(context.Background(), tt.addr, testToken)
+			md, _ := metadata.FromOutgoingContext(out)
+			in := metadata.NewIncomingContext(context.Background(), md)
+
+			if got := FromIncomingContext(in, testToken); got != tt.want {
+				t.Errorf("FromIncomingContext() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromIncomingContextFallsBackToPeer(t *testing.T) {
+	const peerIP = "198.51.100.1"
+
+	tests := []struct {
+		name  string
+		md    metadata.MD
+		token string
+	}{
+		{name: "no metadata", token: testToken},
+		{name: "ip without token", md: metadata.Pairs(MetadataKey, "203.0.113.7"), token: testToken},
+		{name: "wrong token", md: metadata.Pairs(MetadataKey, "203.0.113.7", TokenMetadataKey, "guess"), token: testToken},
+		{name: "empty token", md: metadata.Pairs(MetadataKey, "203.0.113.7", TokenMetadataKey, ""), token: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP(peerIP), Port: 40000},
+			})
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			if got := FromIncomingContext(ctx, tt.token); got != peerIP {
+				t.Errorf("FromIncomingContext() = %q, want %q", got, peerIP)
+			}
+		})
+	}
+}
+
+func TestNewOutgoingContextWithoutToken(t *testing.T) {
+	out := NewOutgoingContext(context.Background(), "203.0.113.7", "")
+	if md, ok := metadata.FromOutgoingContext(out); ok && len(md.Get(MetadataKey)) > 0 {
+		t.Errorf("NewOutgoingContext() without token set %s = %v", MetadataKey, md.Get(MetadataKey))
+	}
+}
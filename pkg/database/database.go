@@ -0,0 +1,102 @@
+// Package database открывает пул соединений с базой и ждёт её готовности при старте сервиса
+package database
+
+import (
+	"context"
+	"database/sql"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Config настройки пула соединений и ожидания базы при старте
+type Config struct {
+	// MaxOpenConns максимум открытых соединений; 0 - без ограничения
+	MaxOpenConns int
+	// MaxIdleConns максимум простаивающих соединений в пуле
+	MaxIdleConns int
+	// ConnMaxLifetime через сколько соединение пересоздаётся; 0 - без ограничения
+	ConnMaxLifetime time.Duration
+	// ConnMaxIdleTime через сколько закрывается простаивающее соединение; 0 - без ограничения
+	ConnMaxIdleTime time.Duration
+
+	// ConnectTimeout сколько ждать базу при старте
+	ConnectTimeout time.Duration
+	// RetryInitial пауза после первой неудачной попытки, дальше она удваивается
+	RetryInitial time.Duration
+	// RetryMax предельная пауза между попытками
+	RetryMax time.Duration
+}
+
+// Open открывает пул соединений и ждёт, пока база ответит на ping.
+// База может подниматься дольше сервиса (docker-compose, рестарт кластера),
+// поэтому ошибка возвращается только после ConnectTimeout.
+func Open(ctx context.Context, driverName, dsn string, cfg Config) (*sql.DB, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := WaitReady(ctx, db, cfg); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// WaitReady повторяет ping с экспоненциальной паузой между попытками,
+// пока база не ответит или не истечёт ConnectTimeout
+func WaitReady(ctx context.Context, db *sql.DB, cfg Config) error {
+	if cfg.ConnectTimeout == 0 {
+		cfg.ConnectTimeout = time.Minute
+	}
+	if cfg.RetryInitial == 0 {
+		cfg.RetryInitial = 200 * time.Millisecond
+	}
+	if cfg.RetryMax == 0 {
+		cfg.RetryMax = 5 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.ConnectTimeout)
+	defer cancel()
+
+	delay := cfg.RetryInitial
+	for attempt := 1; ; attempt++ {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+
+		slog.Warn("database is not ready, retrying",
+			slog.Int("attempt", attempt),
+			slog.Duration("delay", delay),
+			slog.Any("error", err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("database is not ready after %s: %w", cfg.ConnectTimeout, err)
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > cfg.RetryMax {
+			delay = cfg.RetryMax
+		}
+	}
+}
+
+// PublishStats публикует статистику пула через expvar под именем name:
+// открытые и занятые соединения, ожидания свободного соединения и закрытия по лимитам.
+// Как и expvar.Publish, паникует при повторном имени.
+func PublishStats(name string, db *sql.DB) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return db.Stats()
+	}))
+}
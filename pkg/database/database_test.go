@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyDriver отказывает в подключении, пока не исчерпает failures
+type flakyDriver struct {
+	failures atomic.Int32
+	attempts atomic.Int32
+}
+
+func (d *flakyDriver) Open(name string) (driver.Conn, error) {
+	d.attempts.Add(1)
+	if d.failures.Add(-1) >= 0 {
+		return nil, errors.New("connection refused")
+	}
+	return conn{}, nil
+}
+
+type conn struct{}
+
+func (conn) Prepare(query string) (driver.Stmt, error) { return nil, errors.New("not implemented") }
+func (conn) Close() error                              { return nil }
+func (conn) Begin() (driver.Tx, error)                 { return nil, errors.New("not implemented") }
+
+var flaky = &flakyDriver{}
+
+func init() {
+	sql.Register("flaky", flaky)
+}
+
+func TestOpen_Retry(t *testing.T) {
+	flaky.failures.Store(3)
+	flaky.attempts.Store(0)
+
+	db, err := Open(context.Background(), "flaky", "", Config{
+		MaxOpenConns:   4,
+		ConnectTimeout: time.Second,
+		RetryInitial:   time.Millisecond,
+		RetryMax:       2 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer db.Close()
+
+	if got := flaky.attempts.Load(); got != 4 {
+		t.Errorf("connect attempts = %d, want 4", got)
+	}
+	if got := db.Stats().MaxOpenConnections; got != 4 {
+		t.Errorf("MaxOpenConnections = %d, want 4", got)
+	}
+}
+
+func TestOpen_Timeout(t *testing.T) {
+	flaky.failures.Store(1 << 30)
+
+	start := time.Now()
+	_, err := Open(context.Background(), "flaky", "", Config{
+		ConnectTimeout: 50 * time.Millisecond,
+		RetryInitial:   10 * time.Millisecond,
+	})
+	if err == nil {
+		t.Fatal("Open() error = nil, want timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Open() took %s, want about ConnectTimeout", elapsed)
+	}
+}
+
+func TestPublishStats(t *testing.T) {
+	flaky.failures.Store(0)
+
+	db, err := sql.Open("flaky", "")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(7)
+
+	PublishStats("test_db", db)
+
+	var stats sql.DBStats
+	if err := json.Unmarshal([]byte(expvar.Get("test_db").String()), &stats); err != nil {
+		t.Fatalf("failed to decode stats: %v", err)
+	}
+	if stats.MaxOpenConnections != 7 {
+		t.Errorf("MaxOpenConnections = %d, want 7", stats.MaxOpenConnections)
+	}
+}
@@ -0,0 +1,33 @@
+package events
+
+// Типы событий домена auth
+const (
+	TypeUserCreated         = "UserCreated"
+	TypeUserPasswordChanged = "UserPasswordChanged"
+	TypeUserEmailChanged    = "UserEmailChanged"
+	TypeUserDeleted         = "UserDeleted"
+)
+
+// UserCreated публикуется после регистрации пользователя
+type UserCreated struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+// UserPasswordChanged публикуется после смены пароля пользователем
+type UserPasswordChanged struct {
+	UserID string `json:"user_id"`
+}
+
+// UserEmailChanged публикуется, когда пользователь подтвердил новый email и он заменил прежний
+type UserEmailChanged struct {
+	UserID   string `json:"user_id"`
+	OldEmail string `json:"old_email"`
+	NewEmail string `json:"new_email"`
+}
+
+// UserDeleted публикуется после окончательного удаления пользователя.
+// Подписчики удаляют все данные, принадлежащие пользователю.
+type UserDeleted struct {
+	UserID string `json:"user_id"`
+}
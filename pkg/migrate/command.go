@@ -0,0 +1,60 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Usage подсказка по подкоманде migrate
+const Usage = "migrate up|down [N]|status"
+
+// Command выполняет подкоманду migrate сервиса: up, down [N] (по умолчанию 1) или status.
+// Итог печатается в w.
+func Command(ctx context.Context, m *Migrator, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: %s", Usage)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "migrations applied: %d\n", applied)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+			steps = n
+		}
+		reverted, err := m.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "migrations reverted: %d\n", reverted)
+	case "status":
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		if status.Version == 0 {
+			fmt.Fprintln(w, "version: none (no migrations applied)")
+		} else {
+			fmt.Fprintf(w, "version: %d\n", status.Version)
+			fmt.Fprintf(w, "dirty: %t\n", status.Dirty)
+		}
+		fmt.Fprintf(w, "pending: %d\n", len(status.Pending))
+		for _, migration := range status.Pending {
+			fmt.Fprintf(w, "  %04d_%s\n", migration.Version, migration.Name)
+		}
+	default:
+		return fmt.Errorf("unknown command %q, usage: %s", args[0], Usage)
+	}
+	return nil
+}
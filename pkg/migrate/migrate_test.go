@@ -0,0 +1,185 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_second.up.sql":   {Data: []byte("CREATE TABLE b ();")},
+		"0002_second.down.sql": {Data: []byte("DROP TABLE b;")},
+		"0001_first.up.sql":    {Data: []byte("CREATE TABLE a ();")},
+		"migrations.go":        {Data: []byte("package migrations")},
+	}
+
+	got, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := []Migration{
+		{Version: 1, Name: "first", Up: "CREATE TABLE a ();"},
+		{Version: 2, Name: "second", Up: "CREATE TABLE b ();", Down: "DROP TABLE b;"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Load() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Load()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	invalid := []fstest.MapFS{
+		{"0001_first.down.sql": {Data: []byte("DROP TABLE a;")}},
+		{"0001_first.up.sql": {Data: []byte("")}, "0001_other.down.sql": {Data: []byte("")}},
+		{"0000_zero.up.sql": {Data: []byte("SELECT 1;")}},
+	}
+	for _, fsys := range invalid {
+		if _, err := Load(fsys); err == nil {
+			t.Errorf("Load(%v) error = nil, want error", fsys)
+		}
+	}
+}
+
+// openTestDB подключается к Postgres из DB_DSN в отдельной пустой схеме.
+// Тест пропускается, если DB_DSN не задан.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		t.Skip("DB_DSN is not set, skipping Postgres test")
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("migrate_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	db, err := sql.Open("postgres", dsn+sep+"search_path="+schema)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// testMigrations три миграции, последняя меняет таблицу из первой
+var testMigrations = fstest.MapFS{
+	"0001_users.up.sql":         {Data: []byte("CREATE TABLE users (id INT PRIMARY KEY);")},
+	"0001_users.down.sql":       {Data: []byte("DROP TABLE users;")},
+	"0002_notes.up.sql":         {Data: []byte("CREATE TABLE notes (id INT PRIMARY KEY);")},
+	"0002_notes.down.sql":       {Data: []byte("DROP TABLE notes;")},
+	"0003_users_email.up.sql":   {Data: []byte("ALTER TABLE users ADD COLUMN email TEXT;")},
+	"0003_users_email.down.sql": {Data: []byte("ALTER TABLE users DROP COLUMN email;")},
+}
+
+func TestMigrator_UpDown(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	m, err := New(db, testMigrations)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	total := len(m.migrations)
+
+	// Status на чистой базе ничего не создаёт и считает все миграции ожидающими
+	status, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() on empty database error = %v", err)
+	}
+	if status.Version != 0 || len(status.Pending) != total {
+		t.Errorf("Status() on empty database = %+v, want version 0 with %d pending", status, total)
+	}
+	var exists bool
+	if err := db.QueryRow(`SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		t.Fatalf("failed to check schema_migrations: %v", err)
+	}
+	if exists {
+		t.Error("Status() created schema_migrations, want read-only")
+	}
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if applied != total {
+		t.Errorf("Up() applied = %d, want %d", applied, total)
+	}
+	status, err = m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if status.Version != uint64(total) || status.Dirty || len(status.Pending) != 0 {
+		t.Errorf("Status() = %+v, want version %d without pending", status, total)
+	}
+
+	// Повторный запуск ничего не применяет
+	if applied, err := m.Up(ctx); err != nil || applied != 0 {
+		t.Errorf("Up() again = %d, %v, want 0, nil", applied, err)
+	}
+
+	if reverted, err := m.Down(ctx, 1); err != nil || reverted != 1 {
+		t.Fatalf("Down(1) = %d, %v, want 1, nil", reverted, err)
+	}
+	status, err = m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if status.Version != uint64(total-1) || len(status.Pending) != 1 {
+		t.Errorf("Status() after Down(1) = %+v, want version %d with 1 pending", status, total-1)
+	}
+
+	// Откат всех миграций удаляет и таблицы, и версию
+	if reverted, err := m.Down(ctx, total); err != nil || reverted != total-1 {
+		t.Fatalf("Down(all) = %d, %v, want %d, nil", reverted, err, total-1)
+	}
+	var tables int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name <> 'schema_migrations'`).Scan(&tables); err != nil {
+		t.Fatalf("failed to count tables: %v", err)
+	}
+	if tables != 0 {
+		t.Errorf("tables after Down(all) = %d, want 0", tables)
+	}
+
+	// Сбой golang-migrate оставляет dirty версию: Up её не трогает
+	if _, err := db.Exec(`INSERT INTO schema_migrations (version, dirty) VALUES (1, true)`); err != nil {
+		t.Fatalf("failed to mark database dirty: %v", err)
+	}
+	if _, err := m.Up(ctx); !errors.Is(err, ErrDirty) {
+		t.Errorf("Up() on dirty database error = %v, want ErrDirty", err)
+	}
+}
+
+func TestCommand_InvalidArgs(t *testing.T) {
+	m := &Migrator{}
+	for _, args := range [][]string{nil, {"sideways"}, {"down", "0"}, {"down", "many"}} {
+		if err := Command(context.Background(), m, args, io.Discard); err == nil {
+			t.Errorf("Command(%q) error = nil, want usage error", args)
+		}
+	}
+}
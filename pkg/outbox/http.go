@@ -0,0 +1,125 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// envelope формат сообщения при доставке по HTTP
+type envelope struct {
+	ID            string          `json:"id"`
+	AggregateID   string          `json:"aggregate_id"`
+	Type          string          `json:"type"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	CorrelationID string          `json:"correlation_id,omitempty"`
+}
+
+// HTTPPublisher доставляет сообщения подписчику POST-запросом с JSON телом.
+// Ответ не из 2xx считается ошибкой: ретранслятор повторит отправку,
+// поэтому обработчик на стороне подписчика должен быть идемпотентным.
+type HTTPPublisher struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewHTTPPublisher создаёт publisher, отправляющий сообщения на url.
+// token передаётся в заголовке Authorization: Bearer и должен совпадать с токеном Handler.
+func NewHTTPPublisher(url, token string) *HTTPPublisher {
+	return &HTTPPublisher{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Publish отправляет сообщение подписчику
+func (p *HTTPPublisher) Publish(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(envelope{
+		ID:            msg.ID,
+		AggregateID:   msg.AggregateID,
+		Type:          msg.Type,
+		Payload:       msg.Payload,
+		OccurredAt:    msg.OccurredAt,
+		CorrelationID: msg.CorrelationID,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// HandlerFunc обрабатывает сообщение, доставленное HTTPPublisher
+type HandlerFunc func(ctx context.Context, msg Message) error
+
+// Handler принимает сообщения от HTTPPublisher и передаёт их handle.
+// Ошибка handle возвращается отправителю статусом 500, и доставка повторяется.
+// Запросы без совпадающего Bearer токена отклоняются; с пустым token отклоняются все запросы.
+func Handler(token string, handle HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		got := []byte(r.Header.Get("Authorization"))
+		want := []byte("Bearer " + token)
+		if token == "" || subtle.ConstantTimeCompare(got, want) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		var env envelope
+		if err := json.NewDecoder(r.Body).Decode(&env); err != nil {
+			http.Error(w, "invalid message", http.StatusBadRequest)
+			return
+		}
+
+		msg := Message{
+			ID:            env.ID,
+			AggregateID:   env.AggregateID,
+			Type:          env.Type,
+			Payload:       env.Payload,
+			OccurredAt:    env.OccurredAt,
+			CorrelationID: env.CorrelationID,
+		}
+		if err := handle(r.Context(), msg); err != nil {
+			slog.Error("failed to handle outbox message",
+				slog.String("id", msg.ID),
+				slog.String("type", msg.Type),
+				slog.Any("error", err),
+			)
+			http.Error(w, "failed to handle message", http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
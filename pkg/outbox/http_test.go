@@ -0,0 +1,77 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPPublisher(t *testing.T) {
+	var received []Message
+	var failNext bool
+	handler := Handler("secret", func(ctx context.Context, msg Message) error {
+		if failNext {
+			failNext = false
+			return errors.New("consumer unavailable")
+		}
+		received = append(received, msg)
+		return nil
+	})
+
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	ctx := context.Background()
+	msg := Message{
+		ID:            "11111111-1111-1111-1111-111111111111",
+		AggregateID:   "user-1",
+		Type:          "UserDeleted",
+		Payload:       []byte(`{"user_id":"user-1"}`),
+		OccurredAt:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		CorrelationID: "req-1",
+	}
+
+	if err := NewHTTPPublisher(srv.URL, "secret").Publish(ctx, msg); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if len(received) != 1 {
+		t.Fatalf("received %d messages, want 1", len(received))
+	}
+	got := received[0]
+	if got.ID != msg.ID || got.AggregateID != msg.AggregateID || got.Type != msg.Type ||
+		string(got.Payload) != string(msg.Payload) || !got.OccurredAt.Equal(msg.OccurredAt) ||
+		got.CorrelationID != msg.CorrelationID {
+		t.Errorf("received %+v, want %+v", got, msg)
+	}
+
+	// Ошибка обработчика возвращается отправителю, чтобы ретранслятор повторил доставку
+	failNext = true
+	if err := NewHTTPPublisher(srv.URL, "secret").Publish(ctx, msg); err == nil {
+		t.Error("Publish() error = nil when consumer failed")
+	}
+
+	if err := NewHTTPPublisher(srv.URL, "wrong").Publish(ctx, msg); err == nil {
+		t.Error("Publish() error = nil with wrong token")
+	}
+	if err := NewHTTPPublisher(srv.URL, "").Publish(ctx, msg); err == nil {
+		t.Error("Publish() error = nil without token")
+	}
+	if len(received) != 1 {
+		t.Errorf("received %d messages, want 1", len(received))
+	}
+}
+
+func TestHandler_EmptyToken(t *testing.T) {
+	// Незаданный секрет не открывает приём событий: пустой Bearer не подходит
+	srv := httptest.NewServer(Handler("", func(ctx context.Context, msg Message) error {
+		t.Error("handler called without configured token")
+		return nil
+	}))
+	defer srv.Close()
+
+	if err := NewHTTPPublisher(srv.URL, "").Publish(context.Background(), Message{ID: "1"}); err == nil {
+		t.Error("Publish() error = nil when handler has no token")
+	}
+}
@@ -0,0 +1,50 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message запись таблицы outbox
+type Message struct {
+	ID            string
+	AggregateID   string
+	Type          string
+	Payload       []byte
+	OccurredAt    time.Time
+	CorrelationID string
+}
+
+// Publisher публикует сообщения outbox во внешний брокер
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// Execer общий интерфейс *sql.DB и *sql.Tx
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Insert записывает сообщение в outbox. Для атомарности с изменением
+// доменных данных передавайте *sql.Tx той же транзакции.
+func Insert(ctx context.Context, exec Execer, msg Message) error {
+	if msg.ID == "" {
+		msg.ID = uuid.New().String()
+	}
+
+	var correlationID sql.NullString
+	if msg.CorrelationID != "" {
+		correlationID = sql.NullString{String: msg.CorrelationID, Valid: true}
+	}
+
+	query := `
+		INSERT INTO outbox (id, aggregate_id, type, payload, occurred_at, correlation_id)
+		VALUES ($1, $2, $3, $4, NOW(), $5)
+	`
+
+	_, err := exec.ExecContext(ctx, query, msg.ID, msg.AggregateID, msg.Type, msg.Payload, correlationID)
+	return err
+}
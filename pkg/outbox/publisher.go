@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// MemoryPublisher сохраняет опубликованные сообщения в памяти.
+// Используется в тестах и локально, когда брокер не нужен.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewMemoryPublisher создаёт in-memory publisher
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+// Publish сохраняет сообщение
+func (p *MemoryPublisher) Publish(_ context.Context, msg Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+// Messages возвращает копию опубликованных сообщений
+func (p *MemoryPublisher) Messages() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := make([]Message, len(p.messages))
+	copy(out, p.messages)
+	return out
+}
+
+// LogPublisher пишет сообщения в лог. Заглушка до подключения брокера.
+type LogPublisher struct{}
+
+// Publish логирует сообщение
+func (LogPublisher) Publish(_ context.Context, msg Message) error {
+	slog.Info("outbox message published",
+		slog.String("id", msg.ID),
+		slog.String("aggregate_id", msg.AggregateID),
+		slog.String("type", msg.Type),
+	)
+	return nil
+}
@@ -0,0 +1,139 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// RelayConfig конфигурация ретранслятора outbox
+type RelayConfig struct {
+	// Interval период опроса таблицы outbox
+	Interval time.Duration
+	// BatchSize максимальное количество сообщений за одну итерацию
+	BatchSize int
+}
+
+// Relay периодически вычитывает неотправленные сообщения из outbox,
+// публикует их через Publisher и проставляет sent_at.
+// Несколько реплик могут работать одновременно: строки блокируются
+// через FOR UPDATE SKIP LOCKED.
+type Relay struct {
+	db        *sql.DB
+	publisher Publisher
+	interval  time.Duration
+	batchSize int
+}
+
+// NewRelay создаёт новый ретранслятор outbox
+func NewRelay(db *sql.DB, publisher Publisher, cfg RelayConfig) *Relay {
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Second
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 100
+	}
+
+	return &Relay{
+		db:        db,
+		publisher: publisher,
+		interval:  cfg.Interval,
+		batchSize: cfg.BatchSize,
+	}
+}
+
+// Run запускает цикл ретрансляции до отмены контекста
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		// Вычитываем всё накопившееся, не дожидаясь следующего тика
+		for {
+			n, err := r.ProcessBatch(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.Error("outbox relay failed", slog.Any("error", err))
+				}
+				break
+			}
+			if n < r.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessBatch публикует одну пачку неотправленных сообщений и возвращает
+// количество отправленных. Сообщения публикуются в порядке occurred_at;
+// при ошибке публикации пачка обрывается, уже опубликованные помечаются отправленными.
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT id, aggregate_id, type, payload, occurred_at, correlation_id
+		FROM outbox
+		WHERE sent_at IS NULL
+		ORDER BY occurred_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := tx.QueryContext(ctx, query, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var messages []Message
+	for rows.Next() {
+		var (
+			msg           Message
+			correlationID sql.NullString
+		)
+		if err := rows.Scan(&msg.ID, &msg.AggregateID, &msg.Type, &msg.Payload, &msg.OccurredAt, &correlationID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		msg.CorrelationID = correlationID.String
+		messages = append(messages, msg)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	sent := make([]string, 0, len(messages))
+	var publishErr error
+	for _, msg := range messages {
+		if err := r.publisher.Publish(ctx, msg); err != nil {
+			publishErr = fmt.Errorf("failed to publish message %s: %w", msg.ID, err)
+			break
+		}
+		sent = append(sent, msg.ID)
+	}
+
+	if len(sent) > 0 {
+		if _, err := tx.ExecContext(ctx, `UPDATE outbox SET sent_at = NOW() WHERE id = ANY($1)`, pq.Array(sent)); err != nil {
+			return 0, err
+		}
+		if err := tx.Commit(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(sent), publishErr
+}
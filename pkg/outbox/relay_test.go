@@ -0,0 +1,173 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// openTestDB подключается к Postgres из DB_DSN и создаёт таблицу outbox
+// в отдельной схеме. Тест пропускается, если DB_DSN не задан.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		t.Skip("DB_DSN is not set, skipping Postgres test")
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("outbox_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	db, err := sql.Open("postgres", dsn+sep+"search_path="+schema)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(`
+		CREATE TABLE outbox (
+			id UUID PRIMARY KEY,
+			aggregate_id TEXT NOT NULL,
+			type TEXT NOT NULL,
+			payload BYTEA NOT NULL,
+			occurred_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+			sent_at TIMESTAMPTZ NULL,
+			correlation_id TEXT NULL
+		)
+	`)
+	if err != nil {
+		t.Fatalf("failed to create outbox table: %v", err)
+	}
+
+	return db
+}
+
+func countUnsent(t *testing.T, db *sql.DB) int {
+	t.Helper()
+
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM outbox WHERE sent_at IS NULL`).Scan(&n); err != nil {
+		t.Fatalf("failed to count unsent messages: %v", err)
+	}
+	return n
+}
+
+type failingPublisher struct {
+	failOn string
+	inner  *MemoryPublisher
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, msg Message) error {
+	if msg.AggregateID == p.failOn {
+		return errors.New("broker unavailable")
+	}
+	return p.inner.Publish(ctx, msg)
+}
+
+func TestMemoryPublisher(t *testing.T) {
+	p := NewMemoryPublisher()
+
+	if err := p.Publish(context.Background(), Message{ID: "1", Type: "UserCreated"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	msgs := p.Messages()
+	if len(msgs) != 1 || msgs[0].ID != "1" {
+		t.Fatalf("Messages() = %+v, want one message with ID 1", msgs)
+	}
+
+	// Возвращается копия
+	msgs[0].ID = "changed"
+	if p.Messages()[0].ID != "1" {
+		t.Error("Messages() must return a copy")
+	}
+}
+
+func TestRelay_ProcessBatch(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"user-1", "user-2", "user-3"} {
+		err := Insert(ctx, db, Message{AggregateID: id, Type: "UserCreated", Payload: []byte(`{}`)})
+		if err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+	}
+
+	publisher := NewMemoryPublisher()
+	relay := NewRelay(db, publisher, RelayConfig{BatchSize: 2})
+
+	n, err := relay.ProcessBatch(ctx)
+	if err != nil {
+		t.Fatalf("ProcessBatch() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("ProcessBatch() = %d, want 2", n)
+	}
+	if got := countUnsent(t, db); got != 1 {
+		t.Errorf("unsent = %d, want 1", got)
+	}
+
+	n, err = relay.ProcessBatch(ctx)
+	if err != nil {
+		t.Fatalf("ProcessBatch() error = %v", err)
+	}
+	if n != 1 {
+		t.Errorf("ProcessBatch() = %d, want 1", n)
+	}
+	if got := countUnsent(t, db); got != 0 {
+		t.Errorf("unsent = %d, want 0", got)
+	}
+	if got := len(publisher.Messages()); got != 3 {
+		t.Errorf("published = %d, want 3", got)
+	}
+}
+
+func TestRelay_ProcessBatch_PublishError(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"ok", "fail"} {
+		if err := Insert(ctx, db, Message{AggregateID: id, Type: "UserCreated", Payload: []byte(`{}`)}); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+		// Гарантируем порядок occurred_at
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	publisher := &failingPublisher{failOn: "fail", inner: NewMemoryPublisher()}
+	relay := NewRelay(db, publisher, RelayConfig{})
+
+	n, err := relay.ProcessBatch(ctx)
+	if err == nil {
+		t.Fatal("ProcessBatch() expected error")
+	}
+	if n != 1 {
+		t.Errorf("ProcessBatch() = %d, want 1", n)
+	}
+	// Неопубликованное сообщение остаётся в outbox для повторной попытки
+	if got := countUnsent(t, db); got != 1 {
+		t.Errorf("unsent = %d, want 1", got)
+	}
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
-	"database/sql"
+	"context"
+	"expvar"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
@@ -15,37 +18,212 @@ import (
 	authv1 "golang-project/api/proto/gen/go/auth/v1"
 	"golang-project/pkg/auth/jwt"
 	"golang-project/pkg/config"
+	"golang-project/pkg/database"
+	"golang-project/pkg/migrate"
+	"golang-project/pkg/outbox"
+	"golang-project/services/auth-service/internal/deletion"
+	"golang-project/services/auth-service/internal/domain"
 	"golang-project/services/auth-service/internal/hash"
+	"golang-project/services/auth-service/internal/mailer"
 	"golang-project/services/auth-service/internal/repo"
+	"golang-project/services/auth-service/internal/revocation"
 	"golang-project/services/auth-service/internal/service"
+	"golang-project/services/auth-service/internal/throttle"
+	"golang-project/services/auth-service/internal/totp"
+	"golang-project/services/auth-service/internal/validator"
+	"golang-project/services/auth-service/migrations"
 )
 
 func main() {
 	cfg := config.Load()
-	
-	// Подключение к БД
-	db, err := sql.Open("postgres", cfg.DBDSN)
+
+	// Подключение к БД: при старте база может быть ещё недоступна, ждём её до DBConnectTimeout
+	db, err := database.Open(context.Background(), "postgres", cfg.DBDSN, database.Config{
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+		ConnMaxIdleTime: cfg.DBConnMaxIdleTime,
+		ConnectTimeout:  cfg.DBConnectTimeout,
+	})
 	if err != nil {
 		log.Fatalf("failed to connect to database: %v", err)
 	}
 	defer db.Close()
-	
-	if err := db.Ping(); err != nil {
-		log.Fatalf("failed to ping database: %v", err)
+	database.PublishStats("db", db)
+
+	// auth-service migrate up|down [N]|status - управление схемой без запуска сервиса
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrator, err := migrate.New(db, migrations.FS)
+		if err != nil {
+			log.Fatalf("failed to load migrations: %v", err)
+		}
+		if err := migrate.Command(context.Background(), migrator, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		return
+	}
+
+	if cfg.AutoMigrate {
+		migrator, err := migrate.New(db, migrations.FS)
+		if err != nil {
+			log.Fatalf("failed to load migrations: %v", err)
+		}
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			log.Fatalf("failed to apply migrations: %v", err)
+		}
+		log.Printf("migrations applied: %d", applied)
 	}
-	
+
 	// Инициализация JWT Manager
 	jwtManager, err := jwt.NewManagerFromEnv()
 	if err != nil {
 		log.Fatalf("failed to initialize JWT manager: %v", err)
 	}
 	log.Println("JWT manager initialized successfully")
-	
+
 	// Инициализация зависимостей
 	userRepo := repo.NewUserRepo(db)
-	hasher := hash.NewArgon2Hasher()
-	authService := service.NewAuthServer(userRepo, hasher, jwtManager)
-	
+	refreshRepo := repo.NewRefreshTokenRepo(db)
+	roleRepo := repo.NewRoleRepo(db)
+	verificationRepo := repo.NewVerificationTokenRepo(db)
+	passwordResetRepo := repo.NewPasswordResetRepo(db)
+	mfaRepo := repo.NewMFARepo(db)
+	// Кроме Argon2id проверяются bcrypt и scrypt хеши перенесённых пользователей
+	hasher := hash.NewMultiHasher(hash.NewArgon2Hasher(hash.Params{
+		Memory:      cfg.Argon2Memory,
+		Iterations:  cfg.Argon2Iterations,
+		Parallelism: cfg.Argon2Parallelism,
+	}))
+
+	// Хранилище отозванных access токенов
+	var revocationStore domain.RevocationStore
+	switch cfg.RevocationStore {
+	case "postgres":
+		revocationStore = revocation.NewPostgresStore(db)
+	case "memory":
+		revocationStore = revocation.NewMemoryStore()
+	default:
+		log.Fatalf("unknown revocation store %q", cfg.RevocationStore)
+	}
+
+	// Ограничение попыток входа
+	var loginAttempts domain.LoginAttemptStore
+	switch cfg.LoginThrottleStore {
+	case "postgres":
+		loginAttempts = throttle.NewPostgresStore(db)
+	case "memory":
+		loginAttempts = throttle.NewMemoryStore()
+	default:
+		log.Fatalf("unknown login throttle store %q", cfg.LoginThrottleStore)
+	}
+	loginLimiter := throttle.NewLimiter(loginAttempts, throttle.Config{
+		Email: throttle.Policy{
+			MaxFailures: cfg.LoginMaxFailures,
+			BaseLockout: cfg.LoginLockoutBase,
+			MaxLockout:  cfg.LoginLockoutMax,
+		},
+		IP: throttle.Policy{
+			MaxFailures: cfg.LoginMaxFailuresPerIP,
+			BaseLockout: cfg.LoginLockoutBase,
+			MaxLockout:  cfg.LoginLockoutMax,
+		},
+		Window: cfg.LoginFailureWindow,
+	})
+
+	// Доставка писем (до подключения почтового провайдера - лог или файлы)
+	var mailSender domain.Mailer
+	switch cfg.Mailer {
+	case "log":
+		mailSender = mailer.NewLogMailer()
+	case "file":
+		fileMailer, err := mailer.NewFileMailer(cfg.MailerDir)
+		if err != nil {
+			log.Fatalf("failed to initialize file mailer: %v", err)
+		}
+		mailSender = fileMailer
+	default:
+		log.Fatalf("unknown mailer %q", cfg.Mailer)
+	}
+
+	passwordPolicy := &validator.PasswordPolicy{
+		MinLength:      cfg.PasswordMinLength,
+		MaxLength:      cfg.PasswordMaxLength,
+		RequireUpper:   cfg.PasswordRequireUpper,
+		RequireLower:   cfg.PasswordRequireLower,
+		RequireDigit:   cfg.PasswordRequireDigit,
+		RequireSymbol:  cfg.PasswordRequireSymbol,
+		ForbidEmail:    true,
+		MinEntropyBits: cfg.PasswordMinEntropy,
+		ForbidCommon:   true,
+	}
+	if cfg.PasswordBreachedFile != "" {
+		breached, err := validator.NewPwnedRangeFile(cfg.PasswordBreachedFile)
+		if err != nil {
+			log.Fatalf("failed to open breached passwords file: %v", err)
+		}
+		passwordPolicy.Breached = breached
+	}
+
+	// Ключ шифрования TOTP секретов; без него пользователи не могут подключить второй фактор
+	var totpCipher *totp.SecretCipher
+	if cfg.MFASecretKey != "" {
+		key, err := totp.ParseSecretKey(cfg.MFASecretKey)
+		if err != nil {
+			log.Fatalf("invalid MFA_SECRET_KEY: %v", err)
+		}
+		totpCipher, err = totp.NewSecretCipher(key)
+		if err != nil {
+			log.Fatalf("invalid MFA_SECRET_KEY: %v", err)
+		}
+	} else {
+		log.Println("MFA_SECRET_KEY is not set, TOTP enrollment is disabled")
+	}
+
+	authService := service.NewAuthServer(userRepo, refreshRepo, roleRepo, verificationRepo, passwordResetRepo, mfaRepo, revocationStore, loginLimiter, mailSender, hasher, jwtManager, service.Config{
+		RefreshTokenTTL:          cfg.RefreshTokenTTL,
+		Audience:                 cfg.JWTAudience,
+		RequireEmailVerification: cfg.RequireEmailVerification,
+		EnumerationResistant:     cfg.EnumerationResistant,
+		VerificationTokenTTL:     cfg.VerificationTokenTTL,
+		VerificationURL:          cfg.VerificationURL,
+		PasswordResetTokenTTL:    cfg.PasswordResetTokenTTL,
+		PasswordResetURL:         cfg.PasswordResetURL,
+		MFAIssuer:                cfg.MFAIssuer,
+		MFAChallengeTTL:          cfg.MFAChallengeTTL,
+		TOTPCipher:               totpCipher,
+		PasswordPolicy:           passwordPolicy,
+		ClientIPToken:            cfg.ClientIPToken,
+	})
+
+	// Фоновые задачи останавливаются вместе с сервисом
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	// Ретранслятор outbox: доставка подписчику по HTTP или в лог, если подписчик не задан
+	var publisher outbox.Publisher = outbox.LogPublisher{}
+	if cfg.OutboxPublishURL != "" {
+		if cfg.EventsToken == "" {
+			log.Fatalf("EVENTS_TOKEN is required when OUTBOX_PUBLISH_URL is set")
+		}
+		publisher = outbox.NewHTTPPublisher(cfg.OutboxPublishURL, cfg.EventsToken)
+	}
+	relay := outbox.NewRelay(db, publisher, outbox.RelayConfig{
+		Interval: cfg.OutboxPollInterval,
+	})
+	go relay.Run(workersCtx)
+
+	// Очистка denylist от истёкших токенов
+	janitor := revocation.NewJanitor(revocationStore, cfg.RevocationPurgeInterval)
+	go janitor.Run(workersCtx)
+
+	// Окончательное удаление аккаунтов после срока ожидания
+	purger := deletion.NewPurger(userRepo, cfg.UserDeletionGracePeriod, cfg.UserPurgeInterval)
+	go purger.Run(workersCtx)
+
+	// Очистка устаревших счётчиков попыток входа
+	go loginLimiter.Run(workersCtx)
+
 	// Запуск gRPC сервера
 	lis, err := net.Listen("tcp", cfg.GRPCAddr)
 	if err != nil {
@@ -63,11 +241,54 @@ func main() {
 		}
 	}()
 
+	// HTTP сервер для публикации JWKS
+	mux := http.NewServeMux()
+	mux.Handle(jwt.JWKSPath, jwtManager.JWKSHandler())
+	httpServer := &http.Server{
+		Addr:              cfg.JWKSAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		log.Printf("auth JWKS listening on %s", cfg.JWKSAddr)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("failed to serve JWKS: %v", err)
+		}
+	}()
+
+	// Статистика пула соединений (expvar) только на внутреннем адресе и только если он задан:
+	// /debug/vars раскрывает ещё memstats и командную строку процесса
+	var debugServer *http.Server
+	if cfg.DebugAddr != "" {
+		debugMux := http.NewServeMux()
+		debugMux.Handle("/debug/vars", expvar.Handler())
+		debugServer = &http.Server{
+			Addr:              cfg.DebugAddr,
+			Handler:           debugMux,
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+
+		go func() {
+			log.Printf("auth debug listening on %s", cfg.DebugAddr)
+			if err := debugServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("failed to serve debug: %v", err)
+			}
+		}()
+	}
+
 	// Graceful shutdown
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 	<-sigCh
 
 	log.Println("shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	httpServer.Shutdown(shutdownCtx)
+	if debugServer != nil {
+		debugServer.Shutdown(shutdownCtx)
+	}
 	grpcServer.GracefulStop()
-}
\ No newline at end of file
+	stopWorkers()
+}
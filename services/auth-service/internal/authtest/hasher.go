@@ -0,0 +1,72 @@
+package authtest
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// Префиксы хешей Hasher
+const (
+	hashPrefix       = "$test$"
+	legacyHashPrefix = "$legacy$"
+)
+
+var ErrInvalidHash = errors.New("invalid test hash format")
+
+// Hasher быстрый hasher для тестов: соль и SHA-256 вместо Argon2id.
+// Хеши LegacyHash он проверяет, но помечает устаревшими, как MultiHasher
+// помечает хеши других алгоритмов.
+type Hasher struct{}
+
+// NewHasher создаёт тестовый hasher
+func NewHasher() *Hasher {
+	return &Hasher{}
+}
+
+// Hash создаёт хеш формата $test$<salt>$<sha256(salt+password)>
+func (h *Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, 8)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	encodedSalt := hex.EncodeToString(salt)
+	return hashPrefix + encodedSalt + "$" + sum(encodedSalt, password), nil
+}
+
+// Verify проверяет пароль против хеша Hash или LegacyHash
+func (h *Hasher) Verify(password, encodedHash string) (bool, error) {
+	var salt, expected string
+	switch {
+	case strings.HasPrefix(encodedHash, hashPrefix):
+		var ok bool
+		salt, expected, ok = strings.Cut(strings.TrimPrefix(encodedHash, hashPrefix), "$")
+		if !ok {
+			return false, ErrInvalidHash
+		}
+	case strings.HasPrefix(encodedHash, legacyHashPrefix):
+		expected = strings.TrimPrefix(encodedHash, legacyHashPrefix)
+	default:
+		return false, ErrInvalidHash
+	}
+	return subtle.ConstantTimeCompare([]byte(sum(salt, password)), []byte(expected)) == 1, nil
+}
+
+// NeedsRehash сообщает, что хеш создан LegacyHash
+func (h *Hasher) NeedsRehash(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, legacyHashPrefix)
+}
+
+// LegacyHash возвращает хеш пароля "устаревшего алгоритма": Verify его принимает,
+// а NeedsRehash требует пересчитать
+func LegacyHash(password string) string {
+	return legacyHashPrefix + sum("", password)
+}
+
+func sum(salt, password string) string {
+	s := sha256.Sum256([]byte(salt + password))
+	return hex.EncodeToString(s[:])
+}
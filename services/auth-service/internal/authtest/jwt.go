@@ -0,0 +1,30 @@
+package authtest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+
+	"golang-project/pkg/auth/jwt"
+)
+
+// NewJWTConfig возвращает конфигурацию jwt.Manager с новым ключом Ed25519.
+// Менеджеры, созданные из одной конфигурации с разным TTL, проверяют токены друг друга:
+// так в тестах выпускаются заведомо истёкшие токены.
+func NewJWTConfig() (jwt.Config, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return jwt.Config{}, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return jwt.Config{}, err
+	}
+
+	return jwt.Config{
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		Algorithm:  jwt.AlgorithmEdDSA,
+		Issuer:     "auth-service",
+	}, nil
+}
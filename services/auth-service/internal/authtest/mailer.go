@@ -0,0 +1,35 @@
+package authtest
+
+import (
+	"context"
+	"sync"
+
+	"golang-project/services/auth-service/internal/domain"
+)
+
+// Mailer запоминает отправленные письма вместо отправки
+type Mailer struct {
+	mu   sync.Mutex
+	sent []domain.Email
+}
+
+// NewMailer создаёт mailer без писем
+func NewMailer() *Mailer {
+	return &Mailer{}
+}
+
+func (m *Mailer) Send(ctx context.Context, msg domain.Email) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// Sent возвращает письма в порядке отправки
+func (m *Mailer) Sent() []domain.Email {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]domain.Email(nil), m.sent...)
+}
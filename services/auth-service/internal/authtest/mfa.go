@@ -0,0 +1,135 @@
+package authtest
+
+import (
+	"context"
+	"time"
+
+	"golang-project/services/auth-service/internal/domain"
+	"golang-project/services/auth-service/internal/repo"
+)
+
+// challenge токен второго шага входа
+type challenge struct {
+	userID    string
+	expiresAt time.Time
+	attempts  int
+	usedAt    *time.Time
+}
+
+func (s *Store) GetTOTP(ctx context.Context, userID string) (*domain.TOTP, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.totp[userID]
+	if !ok {
+		return nil, repo.ErrTOTPNotFound
+	}
+	c := *t
+	return &c, nil
+}
+
+func (s *Store) SetPendingTOTP(ctx context.Context, userID, secret string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t, ok := s.totp[userID]; ok && t.ConfirmedAt != nil {
+		return repo.ErrTOTPAlreadyConfirmed
+	}
+	s.totp[userID] = &domain.TOTP{UserID: userID, Secret: secret}
+	return nil
+}
+
+func (s *Store) ConfirmTOTP(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.totp[userID]
+	if !ok || t.ConfirmedAt != nil {
+		return repo.ErrTOTPAlreadyConfirmed
+	}
+	now := s.now()
+	t.ConfirmedAt = &now
+	t.LastUsedStep = step
+
+	codes := make(map[string]bool, len(recoveryCodeHashes))
+	for _, codeHash := range recoveryCodeHashes {
+		codes[codeHash] = true
+	}
+	s.recoveryCodes[userID] = codes
+	return nil
+}
+
+func (s *Store) UseTOTPStep(ctx context.Context, userID string, step int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.totp[userID]
+	if !ok || t.ConfirmedAt == nil || t.LastUsedStep >= step {
+		return repo.ErrTOTPStepUsed
+	}
+	t.LastUsedStep = step
+	return nil
+}
+
+func (s *Store) UseRecoveryCode(ctx context.Context, userID, codeHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.recoveryCodes[userID][codeHash] {
+		return repo.ErrRecoveryCodeNotFound
+	}
+	s.recoveryCodes[userID][codeHash] = false
+	return nil
+}
+
+func (s *Store) CreateMFAChallenge(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.challenges[tokenHash] = &challenge{userID: userID, expiresAt: expiresAt}
+	return nil
+}
+
+func (s *Store) GetMFAChallenge(ctx context.Context, tokenHash string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.challenges[tokenHash]
+	if !ok || c.usedAt != nil {
+		return "", repo.ErrMFAChallengeNotFound
+	}
+	if s.now().After(c.expiresAt) {
+		return "", repo.ErrMFAChallengeExpired
+	}
+	return c.userID, nil
+}
+
+func (s *Store) FailMFAChallenge(ctx context.Context, tokenHash string, maxAttempts int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.challenges[tokenHash]
+	if !ok || c.usedAt != nil {
+		return repo.ErrMFAChallengeNotFound
+	}
+	c.attempts++
+	if c.attempts >= maxAttempts {
+		now := s.now()
+		c.usedAt = &now
+		return repo.ErrMFAChallengeExhausted
+	}
+	return nil
+}
+
+func (s *Store) ConsumeMFAChallenge(ctx context.Context, tokenHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.challenges[tokenHash]
+	if !ok || c.usedAt != nil {
+		return repo.ErrMFAChallengeNotFound
+	}
+	now := s.now()
+	c.usedAt = &now
+	return nil
+}
@@ -0,0 +1,285 @@
+// Package authtest содержит in-memory реализации зависимостей сервиса аутентификации
+// для тестов без PostgreSQL. Реализации повторяют поведение пакета repo, включая его ошибки,
+// поэтому сервис обрабатывает их так же, как ответы настоящей базы.
+package authtest
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang-project/services/auth-service/internal/domain"
+	"golang-project/services/auth-service/internal/repo"
+)
+
+// Store хранит пользователей, роли и токены в памяти процесса и реализует
+// все репозитории domain. Одно хранилище передаётся во все параметры NewAuthServer:
+// операции, которые в базе затрагивают несколько таблиц (сброс пароля, удаление
+// пользователя), меняют общее состояние так же, как транзакции repo.
+type Store struct {
+	mu            sync.Mutex
+	users         map[string]*user
+	roles         map[string]map[string]bool
+	refreshTokens map[string]*refreshToken
+	verifications map[string]*oneTimeToken
+	resets        map[string]*oneTimeToken
+	totp          map[string]*domain.TOTP
+	recoveryCodes map[string]map[string]bool
+	challenges    map[string]*challenge
+	now           func() time.Time
+}
+
+type user struct {
+	domain.User
+	deletedAt *time.Time
+}
+
+// NewStore создаёт пустое хранилище
+func NewStore() *Store {
+	return &Store{
+		users:         make(map[string]*user),
+		roles:         make(map[string]map[string]bool),
+		refreshTokens: make(map[string]*refreshToken),
+		verifications: make(map[string]*oneTimeToken),
+		resets:        make(map[string]*oneTimeToken),
+		totp:          make(map[string]*domain.TOTP),
+		recoveryCodes: make(map[string]map[string]bool),
+		challenges:    make(map[string]*challenge),
+		now:           time.Now,
+	}
+}
+
+// VerifyEmail отмечает email пользователя подтверждённым
+func (s *Store) VerifyEmail(userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[userID]
+	if !ok || u.deletedAt != nil {
+		return repo.ErrUserNotFound
+	}
+	s.verifyEmail(u)
+	return nil
+}
+
+func (s *Store) verifyEmail(u *user) {
+	if u.EmailVerifiedAt == nil {
+		now := s.now()
+		u.EmailVerifiedAt = &now
+	}
+}
+
+// PassHash возвращает текущий хеш пароля пользователя, в том числе удалённого
+func (s *Store) PassHash(userID string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[userID]
+	if !ok {
+		return "", false
+	}
+	return u.PassHash, true
+}
+
+func (s *Store) CreateUser(ctx context.Context, email, passHash string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Как и уникальный индекс в базе, удалённый пользователь занимает email до окончательного удаления
+	if s.userByEmail(email, true) != nil {
+		return "", repo.ErrUserExists
+	}
+
+	userID := uuid.New().String()
+	s.users[userID] = &user{User: domain.User{
+		ID:        userID,
+		Email:     email,
+		PassHash:  passHash,
+		CreatedAt: s.now().Format(time.RFC3339),
+	}}
+	return userID, nil
+}
+
+func (s *Store) UserExistsByEmail(ctx context.Context, email string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.userByEmail(email, true) != nil, nil
+}
+
+func (s *Store) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := s.userByEmail(email, false)
+	if u == nil {
+		return nil, repo.ErrUserNotFound
+	}
+	return u.copy(), nil
+}
+
+func (s *Store) GetUserByID(ctx context.Context, userID string) (*domain.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[userID]
+	if !ok || u.deletedAt != nil {
+		return nil, repo.ErrUserNotFound
+	}
+	return u.copy(), nil
+}
+
+func (s *Store) UpdatePassword(ctx context.Context, userID, passHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[userID]
+	if !ok {
+		return repo.ErrUserNotFound
+	}
+	u.PassHash = passHash
+	return nil
+}
+
+func (s *Store) UpdatePasswordHash(ctx context.Context, userID, oldHash, newHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u, ok := s.users[userID]; ok && u.PassHash == oldHash {
+		u.PassHash = newHash
+	}
+	return nil
+}
+
+func (s *Store) SoftDeleteUser(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[userID]
+	if !ok || u.deletedAt != nil {
+		return repo.ErrUserNotFound
+	}
+	now := s.now()
+	u.deletedAt = &now
+
+	for _, t := range s.refreshTokens {
+		if t.userID == userID && t.revokedAt == nil {
+			t.revokedAt = &now
+		}
+	}
+	for _, tokens := range []map[string]*oneTimeToken{s.verifications, s.resets} {
+		for _, t := range tokens {
+			if t.userID == userID && t.usedAt == nil {
+				t.usedAt = &now
+			}
+		}
+	}
+	for _, c := range s.challenges {
+		if c.userID == userID && c.usedAt == nil {
+			c.usedAt = &now
+		}
+	}
+	return nil
+}
+
+func (s *Store) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time, limit int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var userIDs []string
+	for id, u := range s.users {
+		if u.deletedAt != nil && !u.deletedAt.After(deletedBefore) {
+			userIDs = append(userIDs, id)
+		}
+	}
+	sort.Strings(userIDs)
+	if len(userIDs) > limit {
+		userIDs = userIDs[:limit]
+	}
+
+	// Связанные записи удаляются вместе с пользователем, как каскад в базе
+	for _, userID := range userIDs {
+		delete(s.users, userID)
+		delete(s.roles, userID)
+		delete(s.totp, userID)
+		delete(s.recoveryCodes, userID)
+		for hash, t := range s.refreshTokens {
+			if t.userID == userID {
+				delete(s.refreshTokens, hash)
+			}
+		}
+		for _, tokens := range []map[string]*oneTimeToken{s.verifications, s.resets} {
+			for hash, t := range tokens {
+				if t.userID == userID {
+					delete(tokens, hash)
+				}
+			}
+		}
+		for hash, c := range s.challenges {
+			if c.userID == userID {
+				delete(s.challenges, hash)
+			}
+		}
+	}
+	return len(userIDs), nil
+}
+
+// GetUserRoles возвращает роли пользователя в алфавитном порядке
+func (s *Store) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	roles := []string{}
+	for role := range s.roles[userID] {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles, nil
+}
+
+// GrantRole выдаёт роль существующему пользователю. Набор ролей не ограничен.
+func (s *Store) GrantRole(ctx context.Context, userID, role, grantedBy string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[userID]; !ok {
+		return repo.ErrUserNotFound
+	}
+	if s.roles[userID] == nil {
+		s.roles[userID] = make(map[string]bool)
+	}
+	s.roles[userID][role] = true
+	return nil
+}
+
+func (s *Store) RevokeRole(ctx context.Context, userID, role string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.roles[userID], role)
+	return nil
+}
+
+// userByEmail ищет пользователя по email без учёта регистра, как индекс lower(email) в базе;
+// withDeleted - учитывать удалённых
+func (s *Store) userByEmail(email string, withDeleted bool) *user {
+	for _, u := range s.users {
+		if strings.EqualFold(u.Email, email) && (withDeleted || u.deletedAt == nil) {
+			return u
+		}
+	}
+	return nil
+}
+
+func (u *user) copy() *domain.User {
+	c := u.User
+	if u.EmailVerifiedAt != nil {
+		verifiedAt := *u.EmailVerifiedAt
+		c.EmailVerifiedAt = &verifiedAt
+	}
+	return &c
+}
@@ -0,0 +1,203 @@
+package authtest
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang-project/services/auth-service/internal/repo"
+)
+
+type refreshToken struct {
+	userID    string
+	familyID  string
+	expiresAt time.Time
+	usedAt    *time.Time
+	revokedAt *time.Time
+}
+
+// oneTimeToken токен подтверждения email или сброса пароля
+type oneTimeToken struct {
+	userID    string
+	email     string
+	expiresAt time.Time
+	usedAt    *time.Time
+}
+
+func (s *Store) CreateRefreshToken(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.refreshTokens[tokenHash] = &refreshToken{
+		userID:    userID,
+		familyID:  uuid.New().String(),
+		expiresAt: expiresAt,
+	}
+	return nil
+}
+
+func (s *Store) RotateRefreshToken(ctx context.Context, oldHash, newHash string, expiresAt time.Time) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.refreshTokens[oldHash]
+	if !ok {
+		return "", repo.ErrRefreshTokenNotFound
+	}
+	if t.revokedAt != nil {
+		return "", repo.ErrRefreshTokenRevoked
+	}
+	if t.usedAt != nil {
+		s.revokeFamily(t.familyID)
+		return t.userID, repo.ErrRefreshTokenReused
+	}
+	if s.now().After(t.expiresAt) {
+		return "", repo.ErrRefreshTokenExpired
+	}
+
+	now := s.now()
+	t.usedAt = &now
+	s.refreshTokens[newHash] = &refreshToken{
+		userID:    t.userID,
+		familyID:  t.familyID,
+		expiresAt: expiresAt,
+	}
+	return t.userID, nil
+}
+
+func (s *Store) RevokeRefreshTokenFamily(ctx context.Context, userID, tokenHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t, ok := s.refreshTokens[tokenHash]; ok && t.userID == userID {
+		s.revokeFamily(t.familyID)
+	}
+	return nil
+}
+
+func (s *Store) RevokeUserRefreshTokens(ctx context.Context, userID, keepTokenHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keepFamily := ""
+	if t, ok := s.refreshTokens[keepTokenHash]; ok && t.userID == userID {
+		keepFamily = t.familyID
+	}
+	now := s.now()
+	for _, t := range s.refreshTokens {
+		if t.userID == userID && t.familyID != keepFamily && t.revokedAt == nil {
+			t.revokedAt = &now
+		}
+	}
+	return nil
+}
+
+// RefreshTokenRevoked сообщает, отозван ли refresh токен с хешем tokenHash
+func (s *Store) RefreshTokenRevoked(tokenHash string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.refreshTokens[tokenHash]
+	return ok && t.revokedAt != nil
+}
+
+func (s *Store) revokeFamily(familyID string) {
+	now := s.now()
+	for _, t := range s.refreshTokens {
+		if t.familyID == familyID && t.revokedAt == nil {
+			t.revokedAt = &now
+		}
+	}
+}
+
+func (s *Store) CreateVerificationToken(ctx context.Context, userID, email, tokenHash string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.createOneTimeToken(s.verifications, userID, tokenHash, expiresAt)
+	s.verifications[tokenHash].email = email
+	return nil
+}
+
+func (s *Store) ConsumeVerificationToken(ctx context.Context, tokenHash string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.verifications[tokenHash]
+	if !ok {
+		return "", repo.ErrVerificationTokenNotFound
+	}
+	if t.usedAt != nil {
+		return "", repo.ErrVerificationTokenUsed
+	}
+	if s.now().After(t.expiresAt) {
+		return "", repo.ErrVerificationTokenExpired
+	}
+
+	u, ok := s.users[t.userID]
+	if !ok {
+		return "", repo.ErrVerificationTokenNotFound
+	}
+	now := s.now()
+	if t.email != "" && !strings.EqualFold(t.email, u.Email) {
+		// Смена email: адрес применяется только после подтверждения
+		if other := s.userByEmail(t.email, true); other != nil && other.ID != u.ID {
+			return "", repo.ErrUserExists
+		}
+		u.Email = t.email
+		u.EmailVerifiedAt = &now
+	} else {
+		s.verifyEmail(u)
+	}
+	t.usedAt = &now
+	return t.userID, nil
+}
+
+func (s *Store) CreatePasswordResetToken(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.createOneTimeToken(s.resets, userID, tokenHash, expiresAt)
+	return nil
+}
+
+func (s *Store) ResetPassword(ctx context.Context, tokenHash, passHash string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.resets[tokenHash]
+	if !ok {
+		return "", repo.ErrPasswordResetTokenNotFound
+	}
+	if t.usedAt != nil {
+		return "", repo.ErrPasswordResetTokenUsed
+	}
+	if s.now().After(t.expiresAt) {
+		return "", repo.ErrPasswordResetTokenExpired
+	}
+
+	now := s.now()
+	t.usedAt = &now
+	if u, ok := s.users[t.userID]; ok {
+		u.PassHash = passHash
+	}
+	for _, rt := range s.refreshTokens {
+		if rt.userID == t.userID && rt.revokedAt == nil {
+			rt.revokedAt = &now
+		}
+	}
+	return t.userID, nil
+}
+
+// createOneTimeToken сохраняет токен, гася ранее выданные неиспользованные токены пользователя
+func (s *Store) createOneTimeToken(tokens map[string]*oneTimeToken, userID, tokenHash string, expiresAt time.Time) {
+	now := s.now()
+	for _, t := range tokens {
+		if t.userID == userID && t.usedAt == nil {
+			t.usedAt = &now
+		}
+	}
+	tokens[tokenHash] = &oneTimeToken{userID: userID, expiresAt: expiresAt}
+}
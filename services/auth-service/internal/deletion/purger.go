@@ -0,0 +1,67 @@
+package deletion
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"golang-project/services/auth-service/internal/domain"
+)
+
+// purgeBatchSize максимальное количество пользователей, удаляемых одной транзакцией
+const purgeBatchSize = 100
+
+// Purger периодически окончательно удаляет пользователей, у которых истёк
+// срок ожидания после мягкого удаления
+type Purger struct {
+	users       domain.UserRepository
+	gracePeriod time.Duration
+	interval    time.Duration
+}
+
+// NewPurger создаёт уборщик удалённых аккаунтов. По умолчанию срок ожидания
+// 30 дней, проверка раз в час.
+func NewPurger(users domain.UserRepository, gracePeriod, interval time.Duration) *Purger {
+	if gracePeriod == 0 {
+		gracePeriod = 30 * 24 * time.Hour
+	}
+	if interval == 0 {
+		interval = time.Hour
+	}
+	return &Purger{
+		users:       users,
+		gracePeriod: gracePeriod,
+		interval:    interval,
+	}
+}
+
+// Run запускает цикл удаления до отмены контекста
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Вычитываем всё накопившееся, не дожидаясь следующего тика
+		for {
+			purged, err := p.users.PurgeDeletedUsers(ctx, time.Now().Add(-p.gracePeriod), purgeBatchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.Error("failed to purge deleted users", slog.Any("error", err))
+				}
+				break
+			}
+			if purged > 0 {
+				slog.Info("purged deleted users", slog.Int("count", purged))
+			}
+			if purged < purgeBatchSize {
+				break
+			}
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"golang-project/pkg/auth/jwt"
+)
 
 // UserRepository — интерфейс для работы с пользователями
 type UserRepository interface {
@@ -8,25 +13,125 @@ type UserRepository interface {
 	UserExistsByEmail(ctx context.Context, email string) (bool, error)
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	GetUserByID(ctx context.Context, userID string) (*User, error)
+	UpdatePassword(ctx context.Context, userID, passHash string) error
+	UpdatePasswordHash(ctx context.Context, userID, oldHash, newHash string) error
+	SoftDeleteUser(ctx context.Context, userID string) error
+	PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time, limit int) (int, error)
+}
+
+// VerificationTokenRepository — интерфейс для работы с токенами подтверждения email
+type VerificationTokenRepository interface {
+	CreateVerificationToken(ctx context.Context, userID, email, tokenHash string, expiresAt time.Time) error
+	ConsumeVerificationToken(ctx context.Context, tokenHash string) (string, error)
+}
+
+// PasswordResetRepository — интерфейс для работы с токенами сброса пароля
+type PasswordResetRepository interface {
+	CreatePasswordResetToken(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error
+	ResetPassword(ctx context.Context, tokenHash, passHash string) (string, error)
+}
+
+// RefreshTokenRepository — интерфейс для работы с refresh токенами.
+// Токены одного входа образуют семейство: повторное использование
+// уже обменянного токена отзывает всё семейство.
+type RefreshTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error
+	RotateRefreshToken(ctx context.Context, oldHash, newHash string, expiresAt time.Time) (string, error)
+	RevokeRefreshTokenFamily(ctx context.Context, userID, tokenHash string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID, keepTokenHash string) error
+}
+
+// MFARepository — интерфейс для работы со вторым фактором: TOTP секретами,
+// кодами восстановления и токенами второго шага входа
+type MFARepository interface {
+	GetTOTP(ctx context.Context, userID string) (*TOTP, error)
+	SetPendingTOTP(ctx context.Context, userID, secret string) error
+	ConfirmTOTP(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error
+	UseTOTPStep(ctx context.Context, userID string, step int64) error
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) error
+	CreateMFAChallenge(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error
+	GetMFAChallenge(ctx context.Context, tokenHash string) (string, error)
+	FailMFAChallenge(ctx context.Context, tokenHash string, maxAttempts int) error
+	ConsumeMFAChallenge(ctx context.Context, tokenHash string) error
+}
+
+// RoleRepository — интерфейс для работы с ролями пользователей
+type RoleRepository interface {
+	GetUserRoles(ctx context.Context, userID string) ([]string, error)
+	GrantRole(ctx context.Context, userID, role, grantedBy string) error
+	RevokeRole(ctx context.Context, userID, role string) error
 }
 
 // PasswordHasher — интерфейс для хеширования паролей
 type PasswordHasher interface {
 	Hash(password string) (string, error)
 	Verify(password, hash string) (bool, error)
+	// NeedsRehash сообщает, что хеш создан с устаревшими параметрами
+	NeedsRehash(hash string) bool
 }
 
-// TokenGenerator — интерфейс для работы с токенами
+// TokenGenerator — интерфейс выпуска и проверки access токенов
 type TokenGenerator interface {
-	Generate(userID string) (accessToken, refreshToken string, err error)
-	Validate(token string) (userID string, err error)
+	Sign(userID string, opts ...jwt.SignOption) (string, error)
+	Validate(token string, opts ...jwt.ValidateOption) (*jwt.Claims, error)
+	// TTL время жизни выпускаемых токенов
+	TTL() time.Duration
 }
 
+// RevocationStore — хранилище отозванных access токенов (denylist).
+// Запись нужна только до истечения срока токена, дальше его отвергает проверка exp.
+// Отзыв по пользователю гасит все его токены, выпущенные строго раньше issuedBefore.
+type RevocationStore interface {
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	RevokeUser(ctx context.Context, userID string, issuedBefore, expiresAt time.Time) error
+	IsUserRevoked(ctx context.Context, userID string, issuedAt time.Time) (bool, error)
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// Mailer — интерфейс отправки писем пользователям
+type Mailer interface {
+	Send(ctx context.Context, msg Email) error
+}
+
+// Email — письмо пользователю
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// LoginAttemptStore — хранилище неудачных попыток входа по ключу (email или IP).
+// Счётчик ключа начинается заново, если с последней неудачи прошло больше window.
+type LoginAttemptStore interface {
+	RecordFailure(ctx context.Context, key string, window time.Duration) (int, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	LockedUntil(ctx context.Context, key string) (time.Time, error)
+	Reset(ctx context.Context, key string) error
+	PurgeExpired(ctx context.Context, window time.Duration) (int64, error)
+}
+
+// RoleAdmin — роль администратора: управление ролями пользователей
+const RoleAdmin = "admin"
+
 // User — доменная модель пользователя
 type User struct {
 	ID        string
 	Email     string
 	PassHash  string
 	CreatedAt string
+	Roles     []string
+	// EmailVerifiedAt время подтверждения email; nil - email не подтверждён
+	EmailVerifiedAt *time.Time
+}
+
+// TOTP — секрет приложения-аутентификатора пользователя
+type TOTP struct {
+	UserID string
+	Secret string
+	// ConfirmedAt время подтверждения первым кодом; nil - второй фактор ещё не включён
+	ConfirmedAt *time.Time
+	// LastUsedStep последний принятый временной шаг
+	LastUsedStep int64
 }
 
@@ -16,6 +16,28 @@ var (
 	ErrIncompatibleVersion = errors.New("incompatible argon2 version")
 )
 
+// Params параметры Argon2id. Они записываются в каждый хеш, поэтому пароли,
+// захешированные со старыми параметрами, продолжают проверяться после их изменения.
+type Params struct {
+	// Memory объём памяти в KiB
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultParams возвращает параметры по умолчанию: 64 MB, 3 прохода, 2 потока
+func DefaultParams() Params {
+	return Params{
+		Memory:      64 * 1024, // 64 MB
+		Iterations:  3,
+		Parallelism: 2,
+		SaltLength:  16,
+		KeyLength:   32,
+	}
+}
+
 type Argon2Hasher struct {
 	memory      uint32
 	iterations  uint32
@@ -24,13 +46,31 @@ type Argon2Hasher struct {
 	keyLength   uint32
 }
 
-func NewArgon2Hasher() *Argon2Hasher {
+// NewArgon2Hasher создаёт hasher с заданными параметрами; незаданные берутся из DefaultParams
+func NewArgon2Hasher(params Params) *Argon2Hasher {
+	defaults := DefaultParams()
+	if params.Memory == 0 {
+		params.Memory = defaults.Memory
+	}
+	if params.Iterations == 0 {
+		params.Iterations = defaults.Iterations
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = defaults.Parallelism
+	}
+	if params.SaltLength == 0 {
+		params.SaltLength = defaults.SaltLength
+	}
+	if params.KeyLength == 0 {
+		params.KeyLength = defaults.KeyLength
+	}
+
 	return &Argon2Hasher{
-		memory:      64 * 1024, // 64 MB
-		iterations:  3,
-		parallelism: 2,
-		saltLength:  16,
-		keyLength:   32,
+		memory:      params.Memory,
+		iterations:  params.Iterations,
+		parallelism: params.Parallelism,
+		saltLength:  params.SaltLength,
+		keyLength:   params.KeyLength,
 	}
 }
 
@@ -69,50 +109,78 @@ func (h *Argon2Hasher) Hash(password string) (string, error) {
 
 // Verify проверяет пароль против хеша
 func (h *Argon2Hasher) Verify(password, encodedHash string) (bool, error) {
+	params, salt, expectedHash, err := decodeHash(encodedHash)
+	if err != nil {
+		return false, err
+	}
+
+	hash := argon2.IDKey(
+		[]byte(password),
+		salt,
+		params.Iterations,
+		params.Memory,
+		params.Parallelism,
+		params.KeyLength,
+	)
+
+	// Constant-time comparison для защиты от timing attacks
+	if subtle.ConstantTimeCompare(hash, expectedHash) == 1 {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// NeedsRehash сообщает, что хеш создан с параметрами, отличными от текущих,
+// и его стоит пересчитать при следующем успешном входе.
+// Нераспознанный хеш не пересчитывается: пароль по нему всё равно не проверить.
+func (h *Argon2Hasher) NeedsRehash(encodedHash string) bool {
+	params, _, _, err := decodeHash(encodedHash)
+	if err != nil {
+		return false
+	}
+
+	return params.Memory != h.memory ||
+		params.Iterations != h.iterations ||
+		params.Parallelism != h.parallelism ||
+		params.SaltLength != h.saltLength ||
+		params.KeyLength != h.keyLength
+}
+
+// decodeHash разбирает хеш формата $argon2id$v=19$m=...,t=...,p=...$salt$hash
+func decodeHash(encodedHash string) (Params, []byte, []byte, error) {
+	var params Params
+
 	parts := strings.Split(encodedHash, "$")
-	if len(parts) != 6 {
-		return false, ErrInvalidHash
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, ErrInvalidHash
 	}
 
 	var version int
 	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
 	if err != nil {
-		return false, err
+		return params, nil, nil, err
 	}
 	if version != argon2.Version {
-		return false, ErrIncompatibleVersion
+		return params, nil, nil, ErrIncompatibleVersion
 	}
 
-	var memory, iterations uint32
-	var parallelism uint8
-	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism)
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
 	if err != nil {
-		return false, err
+		return params, nil, nil, err
 	}
 
 	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
 	if err != nil {
-		return false, err
+		return params, nil, nil, err
 	}
 
-	expectedHash, err := base64.RawStdEncoding.DecodeString(parts[5])
+	hash, err := base64.RawStdEncoding.DecodeString(parts[5])
 	if err != nil {
-		return false, err
+		return params, nil, nil, err
 	}
 
-	hash := argon2.IDKey(
-		[]byte(password),
-		salt,
-		iterations,
-		memory,
-		parallelism,
-		uint32(len(expectedHash)),
-	)
-
-	// Constant-time comparison для защиты от timing attacks
-	if subtle.ConstantTimeCompare(hash, expectedHash) == 1 {
-		return true, nil
-	}
-
-	return false, nil
-}
\ No newline at end of file
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(hash))
+	return params, salt, hash, nil
+}
@@ -0,0 +1,56 @@
+package hash
+
+import "testing"
+
+// Небольшие параметры, чтобы тест не тратил 64 MB на каждый хеш
+var testParams = Params{Memory: 1024, Iterations: 1, Parallelism: 1}
+
+func TestArgon2HasherVerify(t *testing.T) {
+	h := NewArgon2Hasher(testParams)
+
+	encoded, err := h.Hash("Password123!")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	ok, err := h.Verify("Password123!", encoded)
+	if err != nil || !ok {
+		t.Errorf("Verify(correct) = %v, %v", ok, err)
+	}
+	ok, err = h.Verify("wrong", encoded)
+	if err != nil || ok {
+		t.Errorf("Verify(wrong) = %v, %v", ok, err)
+	}
+	if _, err := h.Verify("Password123!", "$2a$10$notargon2"); err == nil {
+		t.Error("Verify() error = nil for foreign hash")
+	}
+}
+
+func TestArgon2HasherNeedsRehash(t *testing.T) {
+	old := NewArgon2Hasher(testParams)
+	encoded, err := old.Hash("Password123!")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	if old.NeedsRehash(encoded) {
+		t.Error("NeedsRehash() = true for current params")
+	}
+
+	stronger := testParams
+	stronger.Iterations = 2
+	h := NewArgon2Hasher(stronger)
+	if !h.NeedsRehash(encoded) {
+		t.Error("NeedsRehash() = false after iterations changed")
+	}
+
+	// Старый хеш по-прежнему проверяется с новыми параметрами
+	ok, err := h.Verify("Password123!", encoded)
+	if err != nil || !ok {
+		t.Errorf("Verify(old hash) = %v, %v", ok, err)
+	}
+
+	if h.NeedsRehash("garbage") {
+		t.Error("NeedsRehash() = true for invalid hash")
+	}
+}
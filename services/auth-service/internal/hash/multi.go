@@ -0,0 +1,152 @@
+package hash
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/scrypt"
+)
+
+var ErrUnsupportedHash = errors.New("unsupported hash algorithm")
+
+// Verifier проверяет пароль против хеша одного алгоритма
+type Verifier interface {
+	Verify(password, encodedHash string) (bool, error)
+}
+
+// MultiHasher проверяет пароли по хешам разных алгоритмов, выбирая алгоритм
+// по префиксу хеша. Новые хеши всегда создаются Argon2id, а хеши других алгоритмов
+// NeedsRehash помечает устаревшими: пользователи, перенесённые из другой системы,
+// переходят на Argon2id при следующем входе.
+type MultiHasher struct {
+	primary  *Argon2Hasher
+	prefixes []string
+	legacy   map[string]Verifier
+}
+
+// NewMultiHasher создаёт hasher на базе primary. Кроме Argon2id поддерживаются
+// bcrypt ($2a$, $2b$, $2y$) и scrypt в формате passlib ($scrypt$ln=..,r=..,p=..$salt$hash).
+func NewMultiHasher(primary *Argon2Hasher) *MultiHasher {
+	h := &MultiHasher{
+		primary: primary,
+		legacy:  make(map[string]Verifier),
+	}
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		h.Register(prefix, BcryptVerifier{})
+	}
+	h.Register("$scrypt$", ScryptVerifier{})
+	return h
+}
+
+// Register добавляет проверку хешей с префиксом prefix
+func (h *MultiHasher) Register(prefix string, v Verifier) {
+	if _, ok := h.legacy[prefix]; !ok {
+		h.prefixes = append(h.prefixes, prefix)
+	}
+	h.legacy[prefix] = v
+}
+
+// Hash создаёт хеш пароля основным алгоритмом
+func (h *MultiHasher) Hash(password string) (string, error) {
+	return h.primary.Hash(password)
+}
+
+// Verify проверяет пароль алгоритмом, которым создан хеш
+func (h *MultiHasher) Verify(password, encodedHash string) (bool, error) {
+	if isArgon2id(encodedHash) {
+		return h.primary.Verify(password, encodedHash)
+	}
+	if v := h.verifier(encodedHash); v != nil {
+		return v.Verify(password, encodedHash)
+	}
+	return false, ErrUnsupportedHash
+}
+
+// NeedsRehash сообщает, что хеш создан другим алгоритмом или устаревшими параметрами Argon2id
+func (h *MultiHasher) NeedsRehash(encodedHash string) bool {
+	if isArgon2id(encodedHash) {
+		return h.primary.NeedsRehash(encodedHash)
+	}
+	return h.verifier(encodedHash) != nil
+}
+
+func (h *MultiHasher) verifier(encodedHash string) Verifier {
+	for _, prefix := range h.prefixes {
+		if strings.HasPrefix(encodedHash, prefix) {
+			return h.legacy[prefix]
+		}
+	}
+	return nil
+}
+
+func isArgon2id(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$argon2id$")
+}
+
+// BcryptVerifier проверяет хеши bcrypt
+type BcryptVerifier struct{}
+
+// Verify проверяет пароль против хеша bcrypt
+func (BcryptVerifier) Verify(password, encodedHash string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ScryptVerifier проверяет хеши scrypt в формате passlib:
+// $scrypt$ln=<log2 N>,r=<r>,p=<p>$<salt>$<hash>, где salt и hash закодированы
+// base64 без выравнивания с '.' вместо '+'
+type ScryptVerifier struct{}
+
+// Verify проверяет пароль против хеша scrypt
+func (ScryptVerifier) Verify(password, encodedHash string) (bool, error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 5 || parts[1] != "scrypt" {
+		return false, ErrInvalidHash
+	}
+
+	var logN, r, p int
+	if _, err := fmt.Sscanf(parts[2], "ln=%d,r=%d,p=%d", &logN, &r, &p); err != nil {
+		return false, fmt.Errorf("%w: %w", ErrInvalidHash, err)
+	}
+	// Ограничения сверху защищают от хеша, требующего гигабайты памяти (128·N·r байт)
+	// или минуты процессорного времени (растёт с N·r·p): иначе одна запись в users.pass_hash
+	// позволяла бы остановить сервис попытками входа. passlib по умолчанию пишет r=8, p=1.
+	if logN < 1 || logN > 20 || r < 1 || r > 32 || p < 1 || p > 16 {
+		return false, ErrInvalidHash
+	}
+
+	salt, err := decodeAB64(parts[3])
+	if err != nil {
+		return false, err
+	}
+	expectedHash, err := decodeAB64(parts[4])
+	if err != nil {
+		return false, err
+	}
+
+	hash, err := scrypt.Key([]byte(password), salt, 1<<logN, r, p, len(expectedHash))
+	if err != nil {
+		return false, err
+	}
+
+	return subtle.ConstantTimeCompare(hash, expectedHash) == 1, nil
+}
+
+// decodeAB64 декодирует base64 в варианте passlib: '.' вместо '+', без выравнивания
+func decodeAB64(s string) ([]byte, error) {
+	b, err := base64.RawStdEncoding.DecodeString(strings.ReplaceAll(s, ".", "+"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHash, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,99 @@
+package hash
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/scrypt"
+)
+
+func TestMultiHasher(t *testing.T) {
+	h := NewMultiHasher(NewArgon2Hasher(testParams))
+	const password = "Password123!"
+
+	argon, err := h.Hash(password)
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	if !strings.HasPrefix(argon, "$argon2id$") {
+		t.Fatalf("Hash() = %q, want argon2id", argon)
+	}
+
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("bcrypt: %v", err)
+	}
+
+	salt := []byte("0123456789abcdef")
+	key, err := scrypt.Key([]byte(password), salt, 1<<4, 8, 1, 32)
+	if err != nil {
+		t.Fatalf("scrypt: %v", err)
+	}
+	ab64 := func(b []byte) string {
+		return strings.ReplaceAll(base64.RawStdEncoding.EncodeToString(b), "+", ".")
+	}
+	scryptHash := fmt.Sprintf("$scrypt$ln=4,r=8,p=1$%s$%s", ab64(salt), ab64(key))
+
+	tests := []struct {
+		name        string
+		hash        string
+		needsRehash bool
+	}{
+		{"argon2id", argon, false},
+		{"bcrypt", string(bcryptHash), true},
+		{"scrypt", scryptHash, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := h.Verify(password, tt.hash)
+			if err != nil || !ok {
+				t.Errorf("Verify(correct) = %v, %v", ok, err)
+			}
+			ok, err = h.Verify("wrong", tt.hash)
+			if err != nil || ok {
+				t.Errorf("Verify(wrong) = %v, %v", ok, err)
+			}
+			if got := h.NeedsRehash(tt.hash); got != tt.needsRehash {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.needsRehash)
+			}
+		})
+	}
+
+	if _, err := h.Verify(password, "$md5$whatever"); err != ErrUnsupportedHash {
+		t.Errorf("Verify(unknown) error = %v, want ErrUnsupportedHash", err)
+	}
+	if h.NeedsRehash("$md5$whatever") {
+		t.Error("NeedsRehash(unknown) = true")
+	}
+}
+
+func TestScryptVerifier_InvalidParams(t *testing.T) {
+	// Соль и хеш валидны: отказ должен прийти до вызова scrypt.Key
+	const saltAndHash = "$MDEyMzQ1Njc4OWFiY2RlZg$MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY"
+
+	tests := []struct {
+		name   string
+		params string
+	}{
+		{"ln zero", "ln=0,r=8,p=1"},
+		{"ln too large", "ln=21,r=8,p=1"},
+		{"r zero", "ln=4,r=0,p=1"},
+		{"r too large", "ln=4,r=33,p=1"},
+		{"r negative", "ln=4,r=-8,p=1"},
+		{"p zero", "ln=4,r=8,p=0"},
+		{"p too large", "ln=4,r=8,p=17"},
+		{"huge p", "ln=4,r=8,p=1073741823"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ScryptVerifier{}.Verify("Password123!", "$scrypt$"+tt.params+saltAndHash)
+			if !errors.Is(err, ErrInvalidHash) {
+				t.Errorf("Verify() error = %v, want ErrInvalidHash", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang-project/services/auth-service/internal/domain"
+)
+
+// FileMailer сохраняет каждое письмо отдельным .eml файлом в каталоге.
+// Подходит для локальной разработки и тестов, где письма нужно прочитать программно.
+type FileMailer struct {
+	dir string
+	seq atomic.Uint64
+	now func() time.Time
+}
+
+// NewFileMailer создаёт mailer, пишущий письма в dir; каталог создаётся при необходимости
+func NewFileMailer(dir string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create mail dir: %w", err)
+	}
+	return &FileMailer{dir: dir, now: time.Now}, nil
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg domain.Email) error {
+	now := m.now()
+
+	// Имя файла сортируется по времени отправки; счётчик разводит письма в пределах одной наносекунды
+	name := fmt.Sprintf("%s-%06d.eml", now.UTC().Format("20060102T150405.000000000"), m.seq.Add(1))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(msg.Body)
+
+	if err := os.WriteFile(filepath.Join(m.dir, name), []byte(b.String()), 0o600); err != nil {
+		return fmt.Errorf("write mail: %w", err)
+	}
+	return nil
+}
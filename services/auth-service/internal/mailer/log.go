@@ -0,0 +1,26 @@
+package mailer
+
+import (
+	"context"
+	"log/slog"
+
+	"golang-project/services/auth-service/internal/domain"
+)
+
+// LogMailer пишет письма в лог вместо отправки.
+// Используется в разработке: ссылку подтверждения можно взять из логов сервиса.
+type LogMailer struct{}
+
+// NewLogMailer создаёт mailer, пишущий письма в лог
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg domain.Email) error {
+	slog.Info("email sent",
+		slog.String("to", msg.To),
+		slog.String("subject", msg.Subject),
+		slog.String("body", msg.Body),
+	)
+	return nil
+}
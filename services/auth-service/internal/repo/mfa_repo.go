@@ -0,0 +1,256 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang-project/services/auth-service/internal/domain"
+)
+
+var (
+	ErrTOTPNotFound          = errors.New("totp not enrolled")
+	ErrTOTPAlreadyConfirmed  = errors.New("totp already confirmed")
+	ErrTOTPStepUsed          = errors.New("totp code already used")
+	ErrRecoveryCodeNotFound  = errors.New("recovery code not found")
+	ErrMFAChallengeNotFound  = errors.New("mfa challenge not found")
+	ErrMFAChallengeExpired   = errors.New("mfa challenge expired")
+	ErrMFAChallengeExhausted = errors.New("mfa challenge attempts exhausted")
+)
+
+type MFARepo struct {
+	db *sql.DB
+}
+
+func NewMFARepo(db *sql.DB) *MFARepo {
+	return &MFARepo{db: db}
+}
+
+// GetTOTP возвращает TOTP секрет пользователя, подтверждённый или ожидающий подтверждения
+func (r *MFARepo) GetTOTP(ctx context.Context, userID string) (*domain.TOTP, error) {
+	var (
+		totp        domain.TOTP
+		confirmedAt sql.NullTime
+	)
+
+	query := `
+		SELECT user_id, secret, confirmed_at, last_used_step
+		FROM user_totp
+		WHERE user_id = $1
+	`
+
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&totp.UserID, &totp.Secret, &confirmedAt, &totp.LastUsedStep)
+	if err == sql.ErrNoRows {
+		return nil, ErrTOTPNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if confirmedAt.Valid {
+		totp.ConfirmedAt = &confirmedAt.Time
+	}
+
+	return &totp, nil
+}
+
+// SetPendingTOTP сохраняет новый неподтверждённый секрет, заменяя прежний неподтверждённый.
+// Подтверждённый секрет не перезаписывается: возвращается ErrTOTPAlreadyConfirmed.
+func (r *MFARepo) SetPendingTOTP(ctx context.Context, userID, secret string) error {
+	query := `
+		INSERT INTO user_totp (user_id, secret, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+		WHERE user_totp.confirmed_at IS NULL
+	`
+
+	res, err := r.db.ExecContext(ctx, query, userID, secret)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrTOTPAlreadyConfirmed
+	}
+	return nil
+}
+
+// ConfirmTOTP включает TOTP после проверки первого кода и сохраняет хеши кодов восстановления.
+// Шаг проверенного кода запоминается, чтобы его нельзя было использовать при входе.
+func (r *MFARepo) ConfirmTOTP(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	confirmQuery := `
+		UPDATE user_totp
+		SET confirmed_at = NOW(), last_used_step = $2
+		WHERE user_id = $1 AND confirmed_at IS NULL
+	`
+	res, err := tx.ExecContext(ctx, confirmQuery, userID, step)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrTOTPAlreadyConfirmed
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO mfa_recovery_codes (id, user_id, code_hash, created_at)
+		VALUES ($1, $2, $3, NOW())
+	`
+	for _, codeHash := range recoveryCodeHashes {
+		if _, err := tx.ExecContext(ctx, insertQuery, uuid.New().String(), userID, codeHash); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UseTOTPStep запоминает шаг принятого кода. Если код этого или более позднего шага
+// уже принимался, возвращает ErrTOTPStepUsed: перехваченный код нельзя использовать повторно.
+func (r *MFARepo) UseTOTPStep(ctx context.Context, userID string, step int64) error {
+	query := `
+		UPDATE user_totp
+		SET last_used_step = $2
+		WHERE user_id = $1 AND confirmed_at IS NOT NULL AND last_used_step < $2
+	`
+
+	res, err := r.db.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrTOTPStepUsed
+	}
+	return nil
+}
+
+// UseRecoveryCode гасит код восстановления. Каждый код действует один раз.
+func (r *MFARepo) UseRecoveryCode(ctx context.Context, userID, codeHash string) error {
+	query := `
+		UPDATE mfa_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	res, err := r.db.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrRecoveryCodeNotFound
+	}
+	return nil
+}
+
+// CreateMFAChallenge сохраняет хеш токена второго шага входа
+func (r *MFARepo) CreateMFAChallenge(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO mfa_challenges (id, user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+	`
+
+	_, err := r.db.ExecContext(ctx, query, uuid.New().String(), userID, tokenHash, expiresAt)
+	return err
+}
+
+// GetMFAChallenge возвращает ID пользователя, которому выдан действующий токен второго шага
+func (r *MFARepo) GetMFAChallenge(ctx context.Context, tokenHash string) (string, error) {
+	var (
+		userID    string
+		expiresAt time.Time
+		usedAt    sql.NullTime
+	)
+
+	query := `
+		SELECT user_id, expires_at, used_at
+		FROM mfa_challenges
+		WHERE token_hash = $1
+	`
+
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&userID, &expiresAt, &usedAt)
+	if err == sql.ErrNoRows {
+		return "", ErrMFAChallengeNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// Использованный токен неотличим от несуществующего
+	if usedAt.Valid {
+		return "", ErrMFAChallengeNotFound
+	}
+	if time.Now().After(expiresAt) {
+		return "", ErrMFAChallengeExpired
+	}
+
+	return userID, nil
+}
+
+// FailMFAChallenge учитывает неверный код. После maxAttempts неудач токен гасится
+// и возвращается ErrMFAChallengeExhausted: вход придётся начать заново с пароля.
+func (r *MFARepo) FailMFAChallenge(ctx context.Context, tokenHash string, maxAttempts int) error {
+	query := `
+		UPDATE mfa_challenges
+		SET attempts = attempts + 1,
+			used_at = CASE WHEN attempts + 1 >= $2 THEN NOW() END
+		WHERE token_hash = $1 AND used_at IS NULL
+		RETURNING used_at IS NOT NULL
+	`
+
+	var exhausted bool
+	err := r.db.QueryRowContext(ctx, query, tokenHash, maxAttempts).Scan(&exhausted)
+	if err == sql.ErrNoRows {
+		return ErrMFAChallengeNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if exhausted {
+		return ErrMFAChallengeExhausted
+	}
+	return nil
+}
+
+// ConsumeMFAChallenge гасит токен второго шага после успешной проверки кода.
+// Из двух параллельных запросов с одним токеном успешен только один.
+func (r *MFARepo) ConsumeMFAChallenge(ctx context.Context, tokenHash string) error {
+	res, err := r.db.ExecContext(ctx, `UPDATE mfa_challenges SET used_at = NOW() WHERE token_hash = $1 AND used_at IS NULL`, tokenHash)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrMFAChallengeNotFound
+	}
+	return nil
+}
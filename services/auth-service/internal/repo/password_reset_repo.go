@@ -0,0 +1,114 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPasswordResetTokenNotFound = errors.New("password reset token not found")
+	ErrPasswordResetTokenExpired  = errors.New("password reset token expired")
+	ErrPasswordResetTokenUsed     = errors.New("password reset token already used")
+)
+
+type PasswordResetRepo struct {
+	db *sql.DB
+}
+
+func NewPasswordResetRepo(db *sql.DB) *PasswordResetRepo {
+	return &PasswordResetRepo{db: db}
+}
+
+// CreatePasswordResetToken сохраняет хеш токена сброса пароля.
+// Ранее выданные неиспользованные токены пользователя гасятся: действует только последнее письмо.
+func (r *PasswordResetRepo) CreatePasswordResetToken(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	invalidateQuery := `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+	if _, err := tx.ExecContext(ctx, invalidateQuery, userID); err != nil {
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+	`
+	if _, err := tx.ExecContext(ctx, insertQuery, uuid.New().String(), userID, tokenHash, expiresAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ResetPassword гасит токен, заменяет хеш пароля и отзывает все refresh токены пользователя
+// в одной транзакции. Возвращает ID пользователя, которому принадлежал токен.
+func (r *PasswordResetRepo) ResetPassword(ctx context.Context, tokenHash, passHash string) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var (
+		id, userID string
+		expiresAt  time.Time
+		usedAt     sql.NullTime
+	)
+
+	query := `
+		SELECT id, user_id, expires_at, used_at
+		FROM password_reset_tokens
+		WHERE token_hash = $1
+		FOR UPDATE
+	`
+
+	err = tx.QueryRowContext(ctx, query, tokenHash).Scan(&id, &userID, &expiresAt, &usedAt)
+	if err == sql.ErrNoRows {
+		return "", ErrPasswordResetTokenNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if usedAt.Valid {
+		return "", ErrPasswordResetTokenUsed
+	}
+	if time.Now().After(expiresAt) {
+		return "", ErrPasswordResetTokenExpired
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE password_reset_tokens SET used_at = NOW() WHERE id = $1`, id); err != nil {
+		return "", err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET pass_hash = $1 WHERE id = $2`, passHash, userID); err != nil {
+		return "", err
+	}
+
+	revokeQuery := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+	if _, err := tx.ExecContext(ctx, revokeQuery, userID); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,139 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenExpired  = errors.New("refresh token expired")
+	ErrRefreshTokenRevoked  = errors.New("refresh token revoked")
+	ErrRefreshTokenReused   = errors.New("refresh token reused")
+)
+
+type RefreshTokenRepo struct {
+	db *sql.DB
+}
+
+func NewRefreshTokenRepo(db *sql.DB) *RefreshTokenRepo {
+	return &RefreshTokenRepo{db: db}
+}
+
+// CreateRefreshToken сохраняет хеш refresh токена, открывая новое семейство токенов
+func (r *RefreshTokenRepo) CreateRefreshToken(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+	`
+
+	_, err := r.db.ExecContext(ctx, query, uuid.New().String(), userID, uuid.New().String(), tokenHash, expiresAt)
+	return err
+}
+
+// RotateRefreshToken помечает токен использованным и выпускает на его место новый
+// в том же семействе. Повторное предъявление уже использованного токена считается
+// кражей: всё семейство отзывается и возвращается ErrRefreshTokenReused.
+func (r *RefreshTokenRepo) RotateRefreshToken(ctx context.Context, oldHash, newHash string, expiresAt time.Time) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var (
+		id, userID, familyID string
+		tokenExpiresAt       time.Time
+		usedAt, revokedAt    sql.NullTime
+	)
+
+	query := `
+		SELECT id, user_id, family_id, expires_at, used_at, revoked_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+		FOR UPDATE
+	`
+
+	err = tx.QueryRowContext(ctx, query, oldHash).Scan(&id, &userID, &familyID, &tokenExpiresAt, &usedAt, &revokedAt)
+	if err == sql.ErrNoRows {
+		return "", ErrRefreshTokenNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if revokedAt.Valid {
+		return "", ErrRefreshTokenRevoked
+	}
+
+	if usedAt.Valid {
+		revokeQuery := `
+			UPDATE refresh_tokens
+			SET revoked_at = NOW()
+			WHERE family_id = $1 AND revoked_at IS NULL
+		`
+		if _, err := tx.ExecContext(ctx, revokeQuery, familyID); err != nil {
+			return "", err
+		}
+		if err := tx.Commit(); err != nil {
+			return "", err
+		}
+		return userID, ErrRefreshTokenReused
+	}
+
+	if time.Now().After(tokenExpiresAt) {
+		return "", ErrRefreshTokenExpired
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE refresh_tokens SET used_at = NOW() WHERE id = $1`, id); err != nil {
+		return "", err
+	}
+
+	insertQuery := `
+		INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+	`
+	if _, err := tx.ExecContext(ctx, insertQuery, uuid.New().String(), userID, familyID, newHash, expiresAt); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+
+	return userID, nil
+}
+
+// RevokeRefreshTokenFamily отзывает семейство, к которому принадлежит токен пользователя.
+// Неизвестный или чужой токен молча игнорируется.
+func (r *RefreshTokenRepo) RevokeRefreshTokenFamily(ctx context.Context, userID, tokenHash string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE revoked_at IS NULL AND family_id = (
+			SELECT family_id FROM refresh_tokens WHERE token_hash = $1 AND user_id = $2
+		)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, tokenHash, userID)
+	return err
+}
+
+// RevokeUserRefreshTokens отзывает все семейства пользователя, кроме семейства токена keepTokenHash.
+// Пустой, неизвестный или чужой keepTokenHash - отзываются все семейства.
+func (r *RefreshTokenRepo) RevokeUserRefreshTokens(ctx context.Context, userID, keepTokenHash string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL AND family_id IS DISTINCT FROM (
+			SELECT family_id FROM refresh_tokens WHERE token_hash = $2 AND user_id = $1
+		)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, keepTokenHash)
+	return err
+}
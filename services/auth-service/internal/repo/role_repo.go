@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrRoleNotFound = errors.New("role not found")
+)
+
+type RoleRepo struct {
+	db *sql.DB
+}
+
+func NewRoleRepo(db *sql.DB) *RoleRepo {
+	return &RoleRepo{db: db}
+}
+
+// GetUserRoles возвращает роли пользователя в алфавитном порядке
+func (r *RoleRepo) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
+	query := `SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []string{}
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// GrantRole выдаёт пользователю роль. Повторная выдача не считается ошибкой.
+func (r *RoleRepo) GrantRole(ctx context.Context, userID, role, grantedBy string) error {
+	query := `
+		INSERT INTO user_roles (user_id, role, granted_by, granted_at)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, NOW())
+		ON CONFLICT (user_id, role) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, role, grantedBy)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		// Нарушение внешнего ключа: нет такой роли или пользователя
+		if pqErr.Constraint == "user_roles_role_fkey" {
+			return ErrRoleNotFound
+		}
+		return ErrUserNotFound
+	}
+	return err
+}
+
+// RevokeRole отзывает роль у пользователя. Отсутствующая роль не считается ошибкой.
+func (r *RoleRepo) RevokeRole(ctx context.Context, userID, role string) error {
+	query := `DELETE FROM user_roles WHERE user_id = $1 AND role = $2`
+
+	_, err := r.db.ExecContext(ctx, query, userID, role)
+	return err
+}
@@ -3,11 +3,15 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	
+	"golang-project/pkg/events"
+	"golang-project/pkg/outbox"
 	"golang-project/services/auth-service/internal/domain"
 )
 
@@ -25,25 +29,208 @@ func NewUserRepo(db *sql.DB) *UserRepo {
 	return &UserRepo{db: db}
 }
 
+// CreateUser создаёт пользователя и событие UserCreated в outbox в одной транзакции.
+// Занятый email возвращает ErrUserExists: уникальный индекс решает гонку параллельных регистраций.
 func (r *UserRepo) CreateUser(ctx context.Context, email, passHash string) (string, error) {
 	userID := uuid.New().String()
 
+	payload, err := json.Marshal(events.UserCreated{UserID: userID, Email: email})
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
 	query := `
 		INSERT INTO users (id, email, pass_hash, created_at)
 		VALUES ($1, $2, $3, NOW())
 	`
 	
-	_, err := r.db.ExecContext(ctx, query, userID, email, passHash)
+	_, err = tx.ExecContext(ctx, query, userID, email, passHash)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return "", ErrUserExists
+	}
+	if err != nil {
+		return "", err
+	}
+
+	err = outbox.Insert(ctx, tx, outbox.Message{
+		AggregateID: userID,
+		Type:        events.TypeUserCreated,
+		Payload:     payload,
+	})
 	if err != nil {
 		return "", err
 	}
 
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+
 	return userID, nil
 }
 
+// UpdatePassword заменяет хеш пароля и пишет событие UserPasswordChanged в outbox в одной транзакции
+func (r *UserRepo) UpdatePassword(ctx context.Context, userID, passHash string) error {
+	payload, err := json.Marshal(events.UserPasswordChanged{UserID: userID})
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE users SET pass_hash = $1 WHERE id = $2`, passHash, userID)
+	if err != nil {
+		return err
+	}
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrUserNotFound
+	}
+
+	err = outbox.Insert(ctx, tx, outbox.Message{
+		AggregateID: userID,
+		Type:        events.TypeUserPasswordChanged,
+		Payload:     payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdatePasswordHash заменяет хеш того же пароля, пересчитанный с новыми параметрами.
+// Событие не публикуется: пароль не меняется. Если хеш успел измениться
+// (например, пароль сменили параллельно), запись не трогается.
+func (r *UserRepo) UpdatePasswordHash(ctx context.Context, userID, oldHash, newHash string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE users SET pass_hash = $1 WHERE id = $2 AND pass_hash = $3`, newHash, userID, oldHash)
+	return err
+}
+
+// SoftDeleteUser помечает пользователя удалённым и гасит его сессии и одноразовые токены.
+// Данные удаляются окончательно через PurgeDeletedUsers по истечении срока ожидания;
+// до этого email остаётся занятым.
+func (r *UserRepo) SoftDeleteUser(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE users SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, userID)
+	if err != nil {
+		return err
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrUserNotFound
+	}
+
+	queries := []string{
+		`UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`,
+		`UPDATE email_verification_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL`,
+		`UPDATE password_reset_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL`,
+		`UPDATE mfa_challenges SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL`,
+	}
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// PurgeDeletedUsers окончательно удаляет не более limit пользователей, помеченных удалёнными
+// не позже deletedBefore, и пишет для каждого событие UserDeleted в outbox.
+// Связанные записи auth-service удаляются каскадно. Возвращает количество удалённых.
+func (r *UserRepo) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time, limit int) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT id
+		FROM users
+		WHERE deleted_at IS NOT NULL AND deleted_at <= $1
+		ORDER BY deleted_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := tx.QueryContext(ctx, query, deletedBefore, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+
+	for _, userID := range userIDs {
+		payload, err := json.Marshal(events.UserDeleted{UserID: userID})
+		if err != nil {
+			return 0, err
+		}
+
+		err = outbox.Insert(ctx, tx, outbox.Message{
+			AggregateID: userID,
+			Type:        events.TypeUserDeleted,
+			Payload:     payload,
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = ANY($1)`, pq.Array(userIDs)); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return len(userIDs), nil
+}
+
+// UserExistsByEmail проверяет, занят ли email, без учёта регистра. Удалённые пользователи
+// занимают email до окончательного удаления.
 func (r *UserRepo) UserExistsByEmail(ctx context.Context, email string) (bool, error) {
 	var exists bool
-	query := `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)`
+	query := `SELECT EXISTS(SELECT 1 FROM users WHERE lower(email) = lower($1))`
 	
 	err := r.db.QueryRowContext(ctx, query, email).Scan(&exists)
 	if err != nil {
@@ -53,13 +240,17 @@ func (r *UserRepo) UserExistsByEmail(ctx context.Context, email string) (bool, e
 	return exists, nil
 }
 
+// GetUserByEmail ищет пользователя по email без учёта регистра
 func (r *UserRepo) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
-	var user domain.User
+	var (
+		user       domain.User
+		verifiedAt sql.NullTime
+	)
 
 	query := `
-		SELECT id, email, pass_hash, created_at
+		SELECT id, email, pass_hash, created_at, email_verified_at
 		FROM users
-		WHERE email = $1
+		WHERE lower(email) = lower($1) AND deleted_at IS NULL
 	`
 
 	err := r.db.QueryRowContext(ctx, query, email).Scan(
@@ -67,6 +258,7 @@ func (r *UserRepo) GetUserByEmail(ctx context.Context, email string) (*domain.Us
 		&user.Email,
 		&user.PassHash,
 		&user.CreatedAt,
+		&verifiedAt,
 	)
 	if err == sql.ErrNoRows {
 		return nil, ErrUserNotFound
@@ -75,16 +267,23 @@ func (r *UserRepo) GetUserByEmail(ctx context.Context, email string) (*domain.Us
 		return nil, err
 	}
 
+	if verifiedAt.Valid {
+		user.EmailVerifiedAt = &verifiedAt.Time
+	}
+
 	return &user, nil
 }
 
 func (r *UserRepo) GetUserByID(ctx context.Context, userID string) (*domain.User, error) {
-	var user domain.User
+	var (
+		user       domain.User
+		verifiedAt sql.NullTime
+	)
 	
 	query := `
-		SELECT id, email, pass_hash, created_at
+		SELECT id, email, pass_hash, created_at, email_verified_at
 		FROM users
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 	
 	err := r.db.QueryRowContext(ctx, query, userID).Scan(
@@ -92,6 +291,7 @@ func (r *UserRepo) GetUserByID(ctx context.Context, userID string) (*domain.User
 		&user.Email,
 		&user.PassHash,
 		&user.CreatedAt,
+		&verifiedAt,
 	)
 	
 	if err == sql.ErrNoRows {
@@ -101,5 +301,9 @@ func (r *UserRepo) GetUserByID(ctx context.Context, userID string) (*domain.User
 		return nil, err
 	}
 	
+	if verifiedAt.Valid {
+		user.EmailVerifiedAt = &verifiedAt.Time
+	}
+	
 	return &user, nil
 }
@@ -0,0 +1,147 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// openTestDB подключается к Postgres из DB_DSN и применяет все миграции сервиса
+// в отдельной схеме. Тест пропускается, если DB_DSN не задан.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		t.Skip("DB_DSN is not set, skipping Postgres test")
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("repo_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	db, err := sql.Open("postgres", dsn+sep+"search_path="+schema)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	// Имена миграций начинаются с номера, поэтому Glob возвращает их по порядку
+	migrations, err := filepath.Glob("../../migrations/*.up.sql")
+	if err != nil {
+		t.Fatalf("failed to list migrations: %v", err)
+	}
+	for _, path := range migrations {
+		migration, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read migration: %v", err)
+		}
+		if _, err := db.Exec(string(migration)); err != nil {
+			t.Fatalf("failed to apply migration %s: %v", filepath.Base(path), err)
+		}
+	}
+
+	return db
+}
+
+func TestUserRepo_CreateUser_Duplicate(t *testing.T) {
+	repo := NewUserRepo(openTestDB(t))
+	ctx := context.Background()
+
+	userID, err := repo.CreateUser(ctx, "alice@example.com", "hash")
+	if err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+	if _, err := repo.CreateUser(ctx, "alice@example.com", "hash"); err != ErrUserExists {
+		t.Errorf("CreateUser() duplicate error = %v, want ErrUserExists", err)
+	}
+
+	// Email уникален без учёта регистра, и поиск тоже не учитывает регистр
+	if _, err := repo.CreateUser(ctx, "ALICE@example.com", "hash"); err != ErrUserExists {
+		t.Errorf("CreateUser() other case error = %v, want ErrUserExists", err)
+	}
+	user, err := repo.GetUserByEmail(ctx, "Alice@Example.com")
+	if err != nil {
+		t.Fatalf("GetUserByEmail() other case error = %v", err)
+	}
+	if user.ID != userID {
+		t.Errorf("GetUserByEmail() ID = %s, want %s", user.ID, userID)
+	}
+
+	// Удалённый пользователь занимает email до окончательного удаления
+	if err := repo.SoftDeleteUser(ctx, userID); err != nil {
+		t.Fatalf("SoftDeleteUser() error = %v", err)
+	}
+	if _, err := repo.CreateUser(ctx, "alice@example.com", "hash"); err != ErrUserExists {
+		t.Errorf("CreateUser() after soft delete error = %v, want ErrUserExists", err)
+	}
+}
+
+func TestUserRepo_CreateUser_Concurrent(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewUserRepo(db)
+	ctx := context.Background()
+
+	const workers = 10
+	var (
+		wg      sync.WaitGroup
+		start   = make(chan struct{})
+		results = make(chan error, workers)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := repo.CreateUser(ctx, "bob@example.com", "hash")
+			results <- err
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(results)
+
+	var created, exists int
+	for err := range results {
+		switch err {
+		case nil:
+			created++
+		case ErrUserExists:
+			exists++
+		default:
+			t.Errorf("CreateUser() error = %v, want nil or ErrUserExists", err)
+		}
+	}
+	if created != 1 || exists != workers-1 {
+		t.Errorf("created = %d, exists = %d, want 1 and %d", created, exists, workers-1)
+	}
+
+	// Событие UserCreated пишется только для созданного пользователя
+	var events int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM outbox`).Scan(&events); err != nil {
+		t.Fatalf("failed to count outbox events: %v", err)
+	}
+	if events != 1 {
+		t.Errorf("outbox events = %d, want 1", events)
+	}
+}
@@ -0,0 +1,170 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"golang-project/pkg/events"
+	"golang-project/pkg/outbox"
+)
+
+var (
+	ErrVerificationTokenNotFound = errors.New("verification token not found")
+	ErrVerificationTokenExpired  = errors.New("verification token expired")
+	ErrVerificationTokenUsed     = errors.New("verification token already used")
+)
+
+type VerificationTokenRepo struct {
+	db *sql.DB
+}
+
+func NewVerificationTokenRepo(db *sql.DB) *VerificationTokenRepo {
+	return &VerificationTokenRepo{db: db}
+}
+
+// CreateVerificationToken сохраняет хеш токена подтверждения email вместе с адресом, на который он отправлен.
+// Ранее выданные неиспользованные токены пользователя гасятся: действует только последнее письмо.
+func (r *VerificationTokenRepo) CreateVerificationToken(ctx context.Context, userID, email, tokenHash string, expiresAt time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	invalidateQuery := `
+		UPDATE email_verification_tokens
+		SET used_at = NOW()
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+	if _, err := tx.ExecContext(ctx, invalidateQuery, userID); err != nil {
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO email_verification_tokens (id, user_id, email, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+	`
+	if _, err := tx.ExecContext(ctx, insertQuery, uuid.New().String(), userID, email, tokenHash, expiresAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ConsumeVerificationToken гасит токен и отмечает email пользователя подтверждённым.
+// Если токен выдан на новый адрес при смене email, адрес применяется здесь же, а событие
+// UserEmailChanged пишется в outbox в той же транзакции. Занятый к этому моменту адрес
+// возвращает ErrUserExists, токен при этом не гасится.
+// Возвращает ID пользователя, которому принадлежал токен.
+func (r *VerificationTokenRepo) ConsumeVerificationToken(ctx context.Context, tokenHash string) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var (
+		id, userID string
+		email      sql.NullString
+		expiresAt  time.Time
+		usedAt     sql.NullTime
+	)
+
+	query := `
+		SELECT id, user_id, email, expires_at, used_at
+		FROM email_verification_tokens
+		WHERE token_hash = $1
+		FOR UPDATE
+	`
+
+	err = tx.QueryRowContext(ctx, query, tokenHash).Scan(&id, &userID, &email, &expiresAt, &usedAt)
+	if err == sql.ErrNoRows {
+		return "", ErrVerificationTokenNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if usedAt.Valid {
+		return "", ErrVerificationTokenUsed
+	}
+	if time.Now().After(expiresAt) {
+		return "", ErrVerificationTokenExpired
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE email_verification_tokens SET used_at = NOW() WHERE id = $1`, id); err != nil {
+		return "", err
+	}
+
+	var currentEmail string
+	err = tx.QueryRowContext(ctx, `SELECT email FROM users WHERE id = $1 FOR UPDATE`, userID).Scan(&currentEmail)
+	if err == sql.ErrNoRows {
+		return "", ErrVerificationTokenNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if email.Valid && !strings.EqualFold(email.String, currentEmail) {
+		if err := changeEmail(ctx, tx, userID, currentEmail, email.String); err != nil {
+			return "", err
+		}
+		if err := tx.Commit(); err != nil {
+			return "", err
+		}
+		return userID, nil
+	}
+
+	// Повторное подтверждение не сдвигает исходную дату
+	verifyQuery := `
+		UPDATE users
+		SET email_verified_at = COALESCE(email_verified_at, NOW())
+		WHERE id = $1
+	`
+	if _, err := tx.ExecContext(ctx, verifyQuery, userID); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+
+	return userID, nil
+}
+
+// changeEmail применяет подтверждённый новый адрес и пишет событие UserEmailChanged
+func changeEmail(ctx context.Context, tx *sql.Tx, userID, oldEmail, newEmail string) error {
+	query := `
+		UPDATE users
+		SET email = $1, email_verified_at = NOW()
+		WHERE id = $2
+	`
+
+	_, err := tx.ExecContext(ctx, query, newEmail, userID)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		// Адрес заняли, пока письмо ждало подтверждения
+		return ErrUserExists
+	}
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(events.UserEmailChanged{UserID: userID, OldEmail: oldEmail, NewEmail: newEmail})
+	if err != nil {
+		return err
+	}
+
+	return outbox.Insert(ctx, tx, outbox.Message{
+		AggregateID: userID,
+		Type:        events.TypeUserEmailChanged,
+		Payload:     payload,
+	})
+}
@@ -0,0 +1,51 @@
+package revocation
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"golang-project/services/auth-service/internal/domain"
+)
+
+// Janitor периодически удаляет из хранилища записи об истёкших токенах
+type Janitor struct {
+	store    domain.RevocationStore
+	interval time.Duration
+}
+
+// NewJanitor создаёт уборщик; по умолчанию запускается раз в 10 минут
+func NewJanitor(store domain.RevocationStore, interval time.Duration) *Janitor {
+	if interval == 0 {
+		interval = 10 * time.Minute
+	}
+	return &Janitor{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Run запускает цикл очистки до отмены контекста
+func (j *Janitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		purged, err := j.store.PurgeExpired(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.Error("failed to purge revoked tokens", slog.Any("error", err))
+			}
+			continue
+		}
+		if purged > 0 {
+			slog.Info("purged revoked tokens", slog.Int64("count", purged))
+		}
+	}
+}
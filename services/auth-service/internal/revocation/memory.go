@@ -0,0 +1,93 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore хранит отозванные токены в памяти процесса.
+// Подходит для тестов и одного экземпляра сервиса: записи не переживают
+// перезапуск и не видны другим репликам.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	revoked map[string]time.Time
+	users   map[string]userRevocation
+	now     func() time.Time
+}
+
+// userRevocation отзыв всех токенов пользователя, выпущенных раньше issuedBefore
+type userRevocation struct {
+	issuedBefore time.Time
+	expiresAt    time.Time
+}
+
+// NewMemoryStore создаёт пустое in-memory хранилище
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		revoked: make(map[string]time.Time),
+		users:   make(map[string]userRevocation),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.revoked[jti] = expiresAt
+	return nil
+}
+
+func (s *MemoryStore) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	expiresAt, ok := s.revoked[jti]
+	return ok && s.now().Before(expiresAt), nil
+}
+
+func (s *MemoryStore) RevokeUser(ctx context.Context, userID string, issuedBefore, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Повторный отзыв только расширяет границы, но не сужает их
+	current := s.users[userID]
+	if issuedBefore.After(current.issuedBefore) {
+		current.issuedBefore = issuedBefore
+	}
+	if expiresAt.After(current.expiresAt) {
+		current.expiresAt = expiresAt
+	}
+	s.users[userID] = current
+	return nil
+}
+
+func (s *MemoryStore) IsUserRevoked(ctx context.Context, userID string, issuedAt time.Time) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	revocation, ok := s.users[userID]
+	return ok && s.now().Before(revocation.expiresAt) && issuedAt.Before(revocation.issuedBefore), nil
+}
+
+func (s *MemoryStore) PurgeExpired(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var purged int64
+	for jti, expiresAt := range s.revoked {
+		if !now.Before(expiresAt) {
+			delete(s.revoked, jti)
+			purged++
+		}
+	}
+	for userID, revocation := range s.users {
+		if !now.Before(revocation.expiresAt) {
+			delete(s.users, userID)
+			purged++
+		}
+	}
+	return purged, nil
+}
@@ -0,0 +1,83 @@
+package revocation
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// PostgresStore хранит отозванные токены в таблицах revoked_tokens и revoked_users,
+// общих для всех реплик auth-service
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore создаёт хранилище поверх Postgres
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO revoked_tokens (jti, expires_at, revoked_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (jti) DO NOTHING
+	`
+
+	_, err := s.db.ExecContext(ctx, query, jti, expiresAt)
+	return err
+}
+
+func (s *PostgresStore) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+	query := `SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = $1 AND expires_at > NOW())`
+
+	err := s.db.QueryRowContext(ctx, query, jti).Scan(&revoked)
+	return revoked, err
+}
+
+func (s *PostgresStore) RevokeUser(ctx context.Context, userID string, issuedBefore, expiresAt time.Time) error {
+	// Повторный отзыв только расширяет границы, но не сужает их
+	query := `
+		INSERT INTO revoked_users (user_id, issued_before, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET issued_before = GREATEST(revoked_users.issued_before, EXCLUDED.issued_before),
+		    expires_at = GREATEST(revoked_users.expires_at, EXCLUDED.expires_at)
+	`
+
+	_, err := s.db.ExecContext(ctx, query, userID, issuedBefore, expiresAt)
+	return err
+}
+
+func (s *PostgresStore) IsUserRevoked(ctx context.Context, userID string, issuedAt time.Time) (bool, error) {
+	var revoked bool
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM revoked_users
+			WHERE user_id = $1 AND issued_before > $2 AND expires_at > NOW()
+		)
+	`
+
+	err := s.db.QueryRowContext(ctx, query, userID, issuedAt).Scan(&revoked)
+	return revoked, err
+}
+
+func (s *PostgresStore) PurgeExpired(ctx context.Context) (int64, error) {
+	var purged int64
+	for _, query := range []string{
+		`DELETE FROM revoked_tokens WHERE expires_at <= NOW()`,
+		`DELETE FROM revoked_users WHERE expires_at <= NOW()`,
+	} {
+		res, err := s.db.ExecContext(ctx, query)
+		if err != nil {
+			return purged, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return purged, err
+		}
+		purged += n
+	}
+	return purged, nil
+}
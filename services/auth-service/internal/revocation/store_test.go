@@ -0,0 +1,158 @@
+package revocation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+
+	"golang-project/services/auth-service/internal/domain"
+)
+
+// openTestDB подключается к Postgres из DB_DSN и создаёт таблицы revoked_tokens
+// и revoked_users в отдельной схеме. Тест пропускается, если DB_DSN не задан.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		t.Skip("DB_DSN is not set, skipping Postgres test")
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("revocation_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	db, err := sql.Open("postgres", dsn+sep+"search_path="+schema)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	for _, name := range []string{"0004_init_revoked_tokens.up.sql", "0008_init_revoked_users.up.sql"} {
+		migration, err := os.ReadFile("../../migrations/" + name)
+		if err != nil {
+			t.Fatalf("failed to read migration: %v", err)
+		}
+		if _, err := db.Exec(string(migration)); err != nil {
+			t.Fatalf("failed to apply migration %s: %v", name, err)
+		}
+	}
+
+	return db
+}
+
+func TestStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) domain.RevocationStore{
+		"memory": func(t *testing.T) domain.RevocationStore {
+			return NewMemoryStore()
+		},
+		"postgres": func(t *testing.T) domain.RevocationStore {
+			return NewPostgresStore(openTestDB(t))
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			ctx := context.Background()
+
+			if err := store.Revoke(ctx, "active", time.Now().Add(time.Hour)); err != nil {
+				t.Fatalf("Revoke() error = %v", err)
+			}
+			// Повторный отзыв не считается ошибкой
+			if err := store.Revoke(ctx, "active", time.Now().Add(time.Hour)); err != nil {
+				t.Fatalf("Revoke() repeated error = %v", err)
+			}
+			if err := store.Revoke(ctx, "expired", time.Now().Add(-time.Minute)); err != nil {
+				t.Fatalf("Revoke() error = %v", err)
+			}
+
+			tests := []struct {
+				jti  string
+				want bool
+			}{
+				{jti: "active", want: true},
+				{jti: "expired", want: false},
+				{jti: "unknown", want: false},
+			}
+			for _, tt := range tests {
+				got, err := store.IsRevoked(ctx, tt.jti)
+				if err != nil {
+					t.Fatalf("IsRevoked(%q) error = %v", tt.jti, err)
+				}
+				if got != tt.want {
+					t.Errorf("IsRevoked(%q) = %v, want %v", tt.jti, got, tt.want)
+				}
+			}
+
+			// Граница отзыва округляется до секунды, как в AuthServer
+			now := time.Now().Truncate(time.Second)
+			activeUser, expiredUser := uuid.NewString(), uuid.NewString()
+			if err := store.RevokeUser(ctx, activeUser, now, now.Add(time.Hour)); err != nil {
+				t.Fatalf("RevokeUser() error = %v", err)
+			}
+			// Более ранний повторный отзыв не сужает границу
+			if err := store.RevokeUser(ctx, activeUser, now.Add(-time.Hour), now.Add(time.Minute)); err != nil {
+				t.Fatalf("RevokeUser() repeated error = %v", err)
+			}
+			if err := store.RevokeUser(ctx, expiredUser, now, now.Add(-time.Minute)); err != nil {
+				t.Fatalf("RevokeUser() error = %v", err)
+			}
+
+			userTests := []struct {
+				name     string
+				userID   string
+				issuedAt time.Time
+				want     bool
+			}{
+				{name: "issued before revocation", userID: activeUser, issuedAt: now.Add(-time.Minute), want: true},
+				{name: "issued after revocation", userID: activeUser, issuedAt: now.Add(time.Minute), want: false},
+				{name: "issued at revocation time", userID: activeUser, issuedAt: now, want: false},
+				{name: "expired revocation", userID: expiredUser, issuedAt: now.Add(-time.Minute), want: false},
+				{name: "unknown user", userID: uuid.NewString(), issuedAt: now.Add(-time.Minute), want: false},
+			}
+			for _, tt := range userTests {
+				got, err := store.IsUserRevoked(ctx, tt.userID, tt.issuedAt)
+				if err != nil {
+					t.Fatalf("IsUserRevoked(%s) error = %v", tt.name, err)
+				}
+				if got != tt.want {
+					t.Errorf("IsUserRevoked(%s) = %v, want %v", tt.name, got, tt.want)
+				}
+			}
+
+			purged, err := store.PurgeExpired(ctx)
+			if err != nil {
+				t.Fatalf("PurgeExpired() error = %v", err)
+			}
+			if purged != 2 {
+				t.Errorf("PurgeExpired() = %d, want 2", purged)
+			}
+			if revoked, _ := store.IsRevoked(ctx, "active"); !revoked {
+				t.Error("PurgeExpired() removed an active entry")
+			}
+			if revoked, _ := store.IsUserRevoked(ctx, activeUser, now.Add(-time.Minute)); !revoked {
+				t.Error("PurgeExpired() removed an active user entry")
+			}
+		})
+	}
+}
@@ -2,31 +2,133 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	authv1 "golang-project/api/proto/gen/go/auth/v1"
 	"golang-project/pkg/auth/jwt"
-	"golang-project/services/auth-service/internal/hash"
+	"golang-project/pkg/clientip"
+	"golang-project/services/auth-service/internal/domain"
 	"golang-project/services/auth-service/internal/repo"
+	"golang-project/services/auth-service/internal/throttle"
+	"golang-project/services/auth-service/internal/token"
+	"golang-project/services/auth-service/internal/totp"
 	"golang-project/services/auth-service/internal/validator"
 )
 
+// Config настройки сервиса аутентификации
+type Config struct {
+	// RefreshTokenTTL время жизни refresh токена
+	RefreshTokenTTL time.Duration
+	// Audience получатель access токенов (claim aud); пустая строка - без aud
+	Audience string
+	// RequireEmailVerification запрещает вход до подтверждения email
+	RequireEmailVerification bool
+	// VerificationTokenTTL время жизни токена подтверждения email
+	VerificationTokenTTL time.Duration
+	// VerificationURL адрес страницы подтверждения; пустая строка - в письме только токен
+	VerificationURL string
+	// PasswordResetTokenTTL время жизни токена сброса пароля
+	PasswordResetTokenTTL time.Duration
+	// PasswordResetURL адрес страницы сброса пароля; пустая строка - в письме только токен
+	PasswordResetURL string
+	// EnumerationResistant скрывает существование аккаунтов: SignIn отвечает одной ошибкой
+	// на любой отказ, SignUp не сообщает о занятом email. Вход до подтверждения email
+	// в этом режиме запрещён, иначе пара SignUp + SignIn раскрывала бы занятый адрес.
+	EnumerationResistant bool
+	// MFAIssuer название сервиса в приложении-аутентификаторе
+	MFAIssuer string
+	// MFAChallengeTTL время жизни токена второго шага входа
+	MFAChallengeTTL time.Duration
+	// TOTPCipher шифрует TOTP секреты в БД; nil - подключение TOTP недоступно,
+	// а ранее подключённые открытые секреты продолжают работать
+	TOTPCipher *totp.SecretCipher
+	// PasswordPolicy требования к новым паролям; nil - validator.DefaultPasswordPolicy
+	PasswordPolicy *validator.PasswordPolicy
+	// ClientIPToken секрет gateway, подтверждающий IP клиента в metadata; пустая строка -
+	// ограничитель попыток входа видит адрес соединения
+	ClientIPToken string
+}
+
+const (
+	// mfaMaxAttempts количество неверных кодов, после которого токен второго шага гасится
+	mfaMaxAttempts = 5
+	// recoveryCodeCount количество кодов восстановления, выдаваемых при включении TOTP
+	recoveryCodeCount = 10
+)
+
+// errInvalidCredentials единая ошибка SignIn в режиме EnumerationResistant
+var errInvalidCredentials = status.Error(codes.Unauthenticated, "invalid credentials")
+
 type AuthServer struct {
 	authv1.UnimplementedAuthServiceServer
-	repo   *repo.UserRepo
-	hasher *hash.Argon2Hasher
-	jwt    *jwt.Manager
+	repo          domain.UserRepository
+	refreshTokens domain.RefreshTokenRepository
+	roles         domain.RoleRepository
+	verifications domain.VerificationTokenRepository
+	resets        domain.PasswordResetRepository
+	mfa           domain.MFARepository
+	revoked       domain.RevocationStore
+	throttle      *throttle.Limiter
+	mailer        domain.Mailer
+	hasher        domain.PasswordHasher
+	jwt           domain.TokenGenerator
+	cfg           Config
+	// dummyHash хеш случайного пароля: проверка по нему выравнивает время ответа
+	// SignIn для несуществующих пользователей
+	dummyHash string
 }
 
-func NewAuthServer(userRepo *repo.UserRepo, hasher *hash.Argon2Hasher, jwtManager *jwt.Manager) *AuthServer {
+// NewAuthServer создаёт сервис аутентификации. Зависимости передаются интерфейсами domain,
+// поэтому в тестах их можно заменить реализациями из пакета authtest.
+func NewAuthServer(userRepo domain.UserRepository, refreshRepo domain.RefreshTokenRepository, roleRepo domain.RoleRepository, verificationRepo domain.VerificationTokenRepository, passwordResetRepo domain.PasswordResetRepository, mfaRepo domain.MFARepository, revocationStore domain.RevocationStore, loginLimiter *throttle.Limiter, mailer domain.Mailer, hasher domain.PasswordHasher, jwtManager domain.TokenGenerator, cfg Config) *AuthServer {
 	slog.Info("creating auth service")
+	if cfg.RefreshTokenTTL == 0 {
+		cfg.RefreshTokenTTL = 30 * 24 * time.Hour // По умолчанию 30 дней
+	}
+	if cfg.VerificationTokenTTL == 0 {
+		cfg.VerificationTokenTTL = 24 * time.Hour
+	}
+	if cfg.PasswordResetTokenTTL == 0 {
+		cfg.PasswordResetTokenTTL = time.Hour
+	}
+	if cfg.MFAIssuer == "" {
+		cfg.MFAIssuer = "golang-project"
+	}
+	if cfg.MFAChallengeTTL == 0 {
+		cfg.MFAChallengeTTL = 5 * time.Minute
+	}
+	if cfg.PasswordPolicy == nil {
+		cfg.PasswordPolicy = validator.DefaultPasswordPolicy()
+	}
+	dummyHash, err := hasher.Hash(uuid.NewString())
+	if err != nil {
+		slog.Error("failed to create dummy password hash", slog.Any("error", err))
+	}
 	return &AuthServer{
-		repo:   userRepo,
-		hasher: hasher,
-		jwt:    jwtManager,
+		repo:          userRepo,
+		refreshTokens: refreshRepo,
+		roles:         roleRepo,
+		verifications: verificationRepo,
+		resets:        passwordResetRepo,
+		mfa:           mfaRepo,
+		revoked:       revocationStore,
+		throttle:      loginLimiter,
+		mailer:        mailer,
+		hasher:        hasher,
+		jwt:           jwtManager,
+		cfg:           cfg,
+		dummyHash:     dummyHash,
 	}
 }
 
@@ -37,43 +139,52 @@ func (s *AuthServer) SignUp(ctx context.Context, req *authv1.SignUpRequest) (*au
 	slog.Info("sign up attempt", slog.String("op", op), slog.String("email", req.Email))
 	
 	// Валидация
-	if err := validator.ValidateEmail(req.Email); err != nil {
+	email, err := validator.NormalizeEmail(req.Email)
+	if err != nil {
 		slog.Warn("invalid email", slog.String("op", op), slog.String("error", err.Error()))
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	
-	if err := validator.ValidatePassword(req.Password); err != nil {
-		slog.Warn("invalid password", slog.String("op", op), slog.String("error", err.Error()))
-		return nil, status.Error(codes.InvalidArgument, err.Error())
-	}
-	
-	// Проверка существования
-	exists, err := s.repo.UserExistsByEmail(ctx, req.Email)
-	if err != nil {
-		slog.Error("failed to check user existence", slog.String("op", op), slog.Any("error", err))
-		return nil, status.Error(codes.Internal, "internal error")
-	}
-	if exists {
-		slog.Warn("user already exists", slog.String("op", op), slog.String("email", req.Email))
-		return nil, status.Error(codes.AlreadyExists, "user already exists")
+	if err := s.checkPassword(op, "password", req.Password, email); err != nil {
+		return nil, err
 	}
 	
-	// Хеширование пароля
+	// Пароль хешируется и для занятого email: время ответа не зависит от того, занят ли он
 	passHash, err := s.hasher.Hash(req.Password)
 	if err != nil {
 		slog.Error("failed to hash password", slog.String("op", op), slog.Any("error", err))
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 	
-	// Создание пользователя
-	userID, err := s.repo.CreateUser(ctx, req.Email, passHash)
+	// Создание пользователя. Занятость email проверяет уникальный индекс, а не отдельный
+	// запрос: из параллельных регистраций с одним адресом успешна только одна.
+	userID, err := s.repo.CreateUser(ctx, email, passHash)
+	if err == repo.ErrUserExists {
+		slog.Warn("user already exists", slog.String("op", op), slog.String("email", email))
+		if !s.cfg.EnumerationResistant {
+			return nil, status.Error(codes.AlreadyExists, "user already exists")
+		}
+		// Владелец адреса узнаёт о попытке из письма, клиент получает тот же ответ, что и при регистрации
+		if err := s.sendSignUpNotice(ctx, email); err != nil {
+			slog.Error("failed to send sign up notice", slog.String("op", op), slog.Any("error", err))
+		}
+		return &authv1.SignUpResponse{VerificationPending: true}, nil
+	}
 	if err != nil {
 		slog.Error("failed to create user", slog.String("op", op), slog.Any("error", err))
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 	
-	slog.Info("user created", slog.String("op", op), slog.String("user_id", userID), slog.String("email", req.Email))
+	slog.Info("user created", slog.String("op", op), slog.String("user_id", userID), slog.String("email", email))
+	
+	// Ошибка отправки письма не отменяет регистрацию: письмо можно запросить повторно
+	if err := s.sendVerification(ctx, userID, email); err != nil {
+		slog.Error("failed to send verification email", slog.String("op", op), slog.String("user_id", userID), slog.Any("error", err))
+	}
 	
+	if s.cfg.EnumerationResistant {
+		return &authv1.SignUpResponse{VerificationPending: true}, nil
+	}
 	return &authv1.SignUpResponse{UserId: userID}, nil
 }
 
@@ -84,7 +195,8 @@ func (s *AuthServer) SignIn(ctx context.Context, req *authv1.SignInRequest) (*au
 	slog.Info("sign in attempt", slog.String("op", op), slog.String("email", req.Email))
 	
 	// Валидация
-	if err := validator.ValidateEmail(req.Email); err != nil {
+	email, err := validator.NormalizeEmail(req.Email)
+	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	
@@ -92,10 +204,30 @@ func (s *AuthServer) SignIn(ctx context.Context, req *authv1.SignInRequest) (*au
 		return nil, status.Error(codes.InvalidArgument, "password required")
 	}
 	
+	// Защита от перебора: проверка блокировки до обращения к Argon2
+	clientIP := clientip.FromIncomingContext(ctx, s.cfg.ClientIPToken)
+	retryAfter, err := s.throttle.Check(ctx, email, clientIP)
+	if err != nil {
+		slog.Error("failed to check login throttle", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	if retryAfter > 0 {
+		slog.Warn("login throttled", slog.String("op", op), slog.String("email", email), slog.String("ip", clientIP), slog.Duration("retry_after", retryAfter))
+		return nil, throttledError(retryAfter)
+	}
+	
 	// Получить пользователя
-	user, err := s.repo.GetUserByEmail(ctx, req.Email)
+	user, err := s.repo.GetUserByEmail(ctx, email)
 	if err == repo.ErrUserNotFound {
-		slog.Warn("user not found", slog.String("op", op), slog.String("email", req.Email))
+		slog.Warn("user not found", slog.String("op", op), slog.String("email", email))
+		s.recordLoginFailure(ctx, op, email, clientIP)
+		if s.cfg.EnumerationResistant {
+			// Проверка по фиктивному хешу, чтобы время ответа совпадало с неверным паролем
+			if s.dummyHash != "" {
+				s.hasher.Verify(req.Password, s.dummyHash)
+			}
+			return nil, errInvalidCredentials
+		}
 		return nil, status.Error(codes.NotFound, "user not found")
 	}
 	if err != nil {
@@ -110,19 +242,67 @@ func (s *AuthServer) SignIn(ctx context.Context, req *authv1.SignInRequest) (*au
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 	if !valid {
-		slog.Warn("invalid password", slog.String("op", op), slog.String("email", req.Email))
+		slog.Warn("invalid password", slog.String("op", op), slog.String("email", email))
+		s.recordLoginFailure(ctx, op, email, clientIP)
+		if s.cfg.EnumerationResistant {
+			return nil, errInvalidCredentials
+		}
 		return nil, status.Error(codes.Unauthenticated, "invalid password")
 	}
 	
-	// Генерация JWT токена
-	accessToken, err := s.jwt.Sign(user.ID)
+	// Пароль известен только сейчас, поэтому хеш со старыми параметрами или другого
+	// алгоритма (пользователи, перенесённые из другой системы) пересчитывается при входе
+	if s.hasher.NeedsRehash(user.PassHash) {
+		s.rehashPassword(ctx, op, user, req.Password)
+	}
+	
+	mfaEnabled, err := s.mfaEnabled(ctx, user.ID)
 	if err != nil {
-		slog.Error("failed to generate access token", slog.String("op", op), slog.Any("error", err))
-		return nil, status.Error(codes.Internal, "failed to generate token")
+		slog.Error("failed to get user totp", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	// Со вторым фактором счётчик сбрасывает только VerifyMFA: иначе знание пароля
+	// позволяло бы перебирать коды, начиная вход заново
+	if !mfaEnabled {
+		if err := s.throttle.Succeed(ctx, email); err != nil {
+			slog.Error("failed to reset login throttle", slog.String("op", op), slog.Any("error", err))
+		}
+	}
+	
+	// Проверяется после пароля, чтобы не раскрывать статус чужого аккаунта
+	if (s.cfg.RequireEmailVerification || s.cfg.EnumerationResistant) && user.EmailVerifiedAt == nil {
+		slog.Warn("email not verified", slog.String("op", op), slog.String("user_id", user.ID))
+		if s.cfg.EnumerationResistant {
+			return nil, errInvalidCredentials
+		}
+		return nil, status.Error(codes.PermissionDenied, "email not verified")
 	}
 	
-	// TODO: Refresh token будет реализован позже
-	refreshToken := ""
+	// Вместо токенов выдаётся токен второго шага, который VerifyMFA обменяет на них
+	if mfaEnabled {
+		mfaToken, mfaHash, err := token.NewOpaque()
+		if err != nil {
+			slog.Error("failed to generate mfa token", slog.String("op", op), slog.Any("error", err))
+			return nil, status.Error(codes.Internal, "failed to generate token")
+		}
+		if err := s.mfa.CreateMFAChallenge(ctx, user.ID, mfaHash, time.Now().Add(s.cfg.MFAChallengeTTL)); err != nil {
+			slog.Error("failed to store mfa challenge", slog.String("op", op), slog.Any("error", err))
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+		
+		slog.Info("mfa required", slog.String("op", op), slog.String("user_id", user.ID))
+		
+		return &authv1.SignInResponse{
+			MfaRequired: true,
+			MfaToken:    mfaToken,
+		}, nil
+	}
+	
+	accessToken, refreshToken, err := s.issueTokens(ctx, op, user.ID)
+	if err != nil {
+		return nil, err
+	}
 	
 	slog.Info("user signed in", slog.String("op", op), slog.String("user_id", user.ID))
 	
@@ -132,6 +312,102 @@ func (s *AuthServer) SignIn(ctx context.Context, req *authv1.SignInRequest) (*au
 	}, nil
 }
 
+// issueTokens выпускает access токен и refresh токен нового семейства
+func (s *AuthServer) issueTokens(ctx context.Context, op, userID string) (string, string, error) {
+	// Роли попадают в токен, чтобы gateway мог авторизовать запросы без обращения к БД
+	roles, err := s.roles.GetUserRoles(ctx, userID)
+	if err != nil {
+		slog.Error("failed to get user roles", slog.String("op", op), slog.Any("error", err))
+		return "", "", status.Error(codes.Internal, "internal error")
+	}
+	
+	// Генерация JWT токена
+	accessToken, err := s.jwt.Sign(userID, s.signOptions(roles)...)
+	if err != nil {
+		slog.Error("failed to generate access token", slog.String("op", op), slog.Any("error", err))
+		return "", "", status.Error(codes.Internal, "failed to generate token")
+	}
+	
+	// Генерация refresh токена (новое семейство)
+	refreshToken, refreshHash, err := token.NewOpaque()
+	if err != nil {
+		slog.Error("failed to generate refresh token", slog.String("op", op), slog.Any("error", err))
+		return "", "", status.Error(codes.Internal, "failed to generate token")
+	}
+	
+	if err := s.refreshTokens.CreateRefreshToken(ctx, userID, refreshHash, time.Now().Add(s.cfg.RefreshTokenTTL)); err != nil {
+		slog.Error("failed to store refresh token", slog.String("op", op), slog.Any("error", err))
+		return "", "", status.Error(codes.Internal, "internal error")
+	}
+	
+	return accessToken, refreshToken, nil
+}
+
+// rehashPassword пересчитывает хеш пароля в Argon2id с текущими параметрами.
+// Ошибка не мешает входу: хеш будет пересчитан при следующем.
+func (s *AuthServer) rehashPassword(ctx context.Context, op string, user *domain.User, password string) {
+	passHash, err := s.hasher.Hash(password)
+	if err != nil {
+		slog.Error("failed to rehash password", slog.String("op", op), slog.Any("error", err))
+		return
+	}
+	if err := s.repo.UpdatePasswordHash(ctx, user.ID, user.PassHash, passHash); err != nil {
+		slog.Error("failed to store rehashed password", slog.String("op", op), slog.Any("error", err))
+		return
+	}
+	slog.Info("password rehashed", slog.String("op", op), slog.String("user_id", user.ID))
+}
+
+// recordLoginFailure учитывает неудачный вход. Ошибка хранилища не меняет ответ клиенту.
+func (s *AuthServer) recordLoginFailure(ctx context.Context, op, email, clientIP string) {
+	if err := s.throttle.Fail(ctx, email, clientIP); err != nil {
+		slog.Error("failed to record login failure", slog.String("op", op), slog.Any("error", err))
+	}
+}
+
+// throttledError возвращает ResourceExhausted с RetryInfo: через сколько можно повторить вход
+func throttledError(retryAfter time.Duration) error {
+	// Клиенту сообщаются целые секунды с округлением вверх
+	retryAfter = (retryAfter + time.Second - 1).Truncate(time.Second)
+	st, err := status.New(codes.ResourceExhausted, "too many login attempts").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, "too many login attempts")
+	}
+	return st.Err()
+}
+
+// checkPassword проверяет новый пароль по политике. Каждое нарушение передаётся клиенту
+// отдельным FieldViolation в BadRequest: поле field, код нарушения в Reason.
+func (s *AuthServer) checkPassword(op, field, password, email string) error {
+	err := s.cfg.PasswordPolicy.Check(password, email)
+	if err == nil {
+		return nil
+	}
+	
+	var policyErr *validator.PolicyError
+	if !errors.As(err, &policyErr) {
+		slog.Error("failed to check password policy", slog.String("op", op), slog.Any("error", err))
+		return status.Error(codes.Internal, "internal error")
+	}
+	slog.Warn("invalid password", slog.String("op", op), slog.String("error", err.Error()))
+	
+	badRequest := &errdetails.BadRequest{}
+	for _, v := range policyErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: v.Message,
+			Reason:      v.Code,
+		})
+	}
+	st, detailErr := status.New(codes.InvalidArgument, err.Error()).WithDetails(badRequest)
+	if detailErr != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return st.Err()
+}
+
 // ValidateToken проверяет токен
 func (s *AuthServer) ValidateToken(ctx context.Context, req *authv1.ValidateTokenRequest) (*authv1.ValidateTokenResponse, error) {
 	op := "ValidateToken"
@@ -143,16 +419,20 @@ func (s *AuthServer) ValidateToken(ctx context.Context, req *authv1.ValidateToke
 	}
 	
 	// Валидация JWT токена
-	claims, err := s.jwt.Validate(req.Token)
+	var opts []jwt.ValidateOption
+	if req.Audience != "" {
+		opts = append(opts, jwt.WithRequiredAudience(req.Audience))
+	}
+	claims, err := s.jwt.Validate(req.Token, opts...)
 	if err != nil {
-		if err == jwt.ErrExpiredToken {
+		if errors.Is(err, jwt.ErrExpiredToken) {
 			slog.Warn("token expired", slog.String("op", op))
 			return &authv1.ValidateTokenResponse{
 				UserId: "",
 				Valid:  false,
 			}, nil
 		}
-		if err == jwt.ErrInvalidToken {
+		if errors.Is(err, jwt.ErrInvalidToken) {
 			slog.Warn("invalid token", slog.String("op", op), slog.Any("error", err))
 			return &authv1.ValidateTokenResponse{
 				UserId: "",
@@ -163,10 +443,701 @@ func (s *AuthServer) ValidateToken(ctx context.Context, req *authv1.ValidateToke
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 	
+	// Проверка denylist: токен мог быть отозван через SignOut или сброс пароля
+	revoked, err := s.isRevoked(ctx, claims)
+	if err != nil {
+		slog.Error("failed to check token revocation", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	if revoked {
+		slog.Warn("token revoked", slog.String("op", op), slog.String("user_id", claims.UserID))
+		return &authv1.ValidateTokenResponse{
+			UserId: "",
+			Valid:  false,
+		}, nil
+	}
+	
 	slog.Info("token validated", slog.String("op", op), slog.String("user_id", claims.UserID))
 	
 	return &authv1.ValidateTokenResponse{
 		UserId: claims.UserID,
 		Valid:  true,
+		Roles:  claims.Roles,
+		Scopes: claims.Scopes,
 	}, nil
 }
+
+// RefreshToken обменивает refresh токен на новую пару токенов.
+// Каждый refresh токен одноразовый; повторное использование отзывает всё семейство.
+func (s *AuthServer) RefreshToken(ctx context.Context, req *authv1.RefreshTokenRequest) (*authv1.RefreshTokenResponse, error) {
+	op := "RefreshToken"
+	
+	slog.Info("refresh token", slog.String("op", op))
+	
+	if req.RefreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh token required")
+	}
+	
+	newRefreshToken, newHash, err := token.NewOpaque()
+	if err != nil {
+		slog.Error("failed to generate refresh token", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "failed to generate token")
+	}
+	
+	userID, err := s.refreshTokens.RotateRefreshToken(ctx, token.Hash(req.RefreshToken), newHash, time.Now().Add(s.cfg.RefreshTokenTTL))
+	switch err {
+	case nil:
+	case repo.ErrRefreshTokenReused:
+		slog.Warn("refresh token reuse detected, family revoked", slog.String("op", op), slog.String("user_id", userID))
+		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+	case repo.ErrRefreshTokenNotFound, repo.ErrRefreshTokenExpired, repo.ErrRefreshTokenRevoked:
+		slog.Warn("invalid refresh token", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+	default:
+		slog.Error("failed to rotate refresh token", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	// Роли перечитываются при каждом обновлении: изменения вступают в силу с новым access токеном
+	roles, err := s.roles.GetUserRoles(ctx, userID)
+	if err != nil {
+		slog.Error("failed to get user roles", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	accessToken, err := s.jwt.Sign(userID, s.signOptions(roles)...)
+	if err != nil {
+		slog.Error("failed to generate access token", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "failed to generate token")
+	}
+	
+	slog.Info("token refreshed", slog.String("op", op), slog.String("user_id", userID))
+	
+	return &authv1.RefreshTokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken,
+	}, nil
+}
+
+// SignOut завершает сессию: access токен попадает в denylist до истечения срока,
+// а семейство переданного refresh токена отзывается
+func (s *AuthServer) SignOut(ctx context.Context, req *authv1.SignOutRequest) (*authv1.SignOutResponse, error) {
+	op := "SignOut"
+	
+	slog.Info("sign out", slog.String("op", op))
+	
+	if req.AccessToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "access token required")
+	}
+	
+	claims, err := s.jwt.Validate(req.AccessToken)
+	if err != nil {
+		if errors.Is(err, jwt.ErrExpiredToken) || errors.Is(err, jwt.ErrInvalidToken) {
+			slog.Warn("invalid token", slog.String("op", op), slog.Any("error", err))
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		slog.Error("failed to validate token", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	// Токены, выпущенные до появления jti, отозвать нельзя: они доживут до exp
+	if claims.ID != "" && claims.ExpiresAt != nil {
+		if err := s.revoked.Revoke(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+			slog.Error("failed to revoke access token", slog.String("op", op), slog.Any("error", err))
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+	} else {
+		slog.Warn("access token has no jti, cannot revoke", slog.String("op", op), slog.String("user_id", claims.UserID))
+	}
+	
+	if req.RefreshToken != "" {
+		if err := s.refreshTokens.RevokeRefreshTokenFamily(ctx, claims.UserID, token.Hash(req.RefreshToken)); err != nil {
+			slog.Error("failed to revoke refresh token", slog.String("op", op), slog.Any("error", err))
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+	}
+	
+	slog.Info("user signed out", slog.String("op", op), slog.String("user_id", claims.UserID))
+	
+	return &authv1.SignOutResponse{Ok: true}, nil
+}
+
+// VerifyEmail подтверждает email по одноразовому токену из письма.
+// Токен, выданный при смене email, заодно применяет новый адрес.
+func (s *AuthServer) VerifyEmail(ctx context.Context, req *authv1.VerifyEmailRequest) (*authv1.VerifyEmailResponse, error) {
+	op := "VerifyEmail"
+	
+	slog.Info("verify email", slog.String("op", op))
+	
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "verification token required")
+	}
+	
+	userID, err := s.verifications.ConsumeVerificationToken(ctx, token.Hash(req.Token))
+	switch err {
+	case nil:
+	case repo.ErrVerificationTokenExpired:
+		slog.Warn("verification token expired", slog.String("op", op))
+		return nil, status.Error(codes.InvalidArgument, "verification token expired")
+	case repo.ErrVerificationTokenNotFound, repo.ErrVerificationTokenUsed:
+		slog.Warn("invalid verification token", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.InvalidArgument, "invalid verification token")
+	case repo.ErrUserExists:
+		slog.Warn("email already taken", slog.String("op", op))
+		return nil, status.Error(codes.AlreadyExists, "user already exists")
+	default:
+		slog.Error("failed to consume verification token", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	slog.Info("email verified", slog.String("op", op), slog.String("user_id", userID))
+	
+	return &authv1.VerifyEmailResponse{UserId: userID}, nil
+}
+
+// ResendVerification отправляет новое письмо подтверждения, гася предыдущие токены.
+// Ответ не зависит от того, существует ли пользователь и подтверждён ли его email.
+func (s *AuthServer) ResendVerification(ctx context.Context, req *authv1.ResendVerificationRequest) (*authv1.ResendVerificationResponse, error) {
+	op := "ResendVerification"
+	
+	slog.Info("resend verification", slog.String("op", op), slog.String("email", req.Email))
+	
+	email, err := validator.NormalizeEmail(req.Email)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	
+	user, err := s.repo.GetUserByEmail(ctx, email)
+	if err == repo.ErrUserNotFound {
+		slog.Warn("user not found", slog.String("op", op), slog.String("email", email))
+		return &authv1.ResendVerificationResponse{Ok: true}, nil
+	}
+	if err != nil {
+		slog.Error("failed to get user", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	if user.EmailVerifiedAt != nil {
+		slog.Info("email already verified", slog.String("op", op), slog.String("user_id", user.ID))
+		return &authv1.ResendVerificationResponse{Ok: true}, nil
+	}
+	
+	if err := s.sendVerification(ctx, user.ID, user.Email); err != nil {
+		slog.Error("failed to send verification email", slog.String("op", op), slog.String("user_id", user.ID), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	return &authv1.ResendVerificationResponse{Ok: true}, nil
+}
+
+// sendVerification выпускает токен подтверждения адреса email и отправляет его письмом на этот адрес
+func (s *AuthServer) sendVerification(ctx context.Context, userID, email string) error {
+	verificationToken, verificationHash, err := token.NewOpaque()
+	if err != nil {
+		return err
+	}
+	
+	if err := s.verifications.CreateVerificationToken(ctx, userID, email, verificationHash, time.Now().Add(s.cfg.VerificationTokenTTL)); err != nil {
+		return err
+	}
+	
+	return s.mailer.Send(ctx, domain.Email{
+		To:      email,
+		Subject: "Подтверждение email",
+		Body:    s.verificationBody(verificationToken),
+	})
+}
+
+// sendSignUpNotice сообщает владельцу email о попытке повторной регистрации
+func (s *AuthServer) sendSignUpNotice(ctx context.Context, email string) error {
+	body := "Кто-то пытается зарегистрироваться с вашим email. Аккаунт с этим адресом уже существует.\n\n" +
+		"Если это были вы, войдите в аккаунт или восстановите пароль. Иначе просто проигнорируйте это письмо.\n"
+	
+	return s.mailer.Send(ctx, domain.Email{
+		To:      email,
+		Subject: "Попытка регистрации",
+		Body:    body,
+	})
+}
+
+// verificationBody формирует текст письма подтверждения email
+func (s *AuthServer) verificationBody(verificationToken string) string {
+	if link, ok := tokenLink(s.cfg.VerificationURL, verificationToken); ok {
+		return fmt.Sprintf("Для подтверждения email перейдите по ссылке:\n\n%s\n\nСсылка действует %s.\n", link, s.cfg.VerificationTokenTTL)
+	}
+	return fmt.Sprintf("Для подтверждения email используйте токен:\n\n%s\n\nТокен действует %s.\n", verificationToken, s.cfg.VerificationTokenTTL)
+}
+
+// RequestPasswordReset отправляет письмо с токеном сброса пароля.
+// Ответ не зависит от того, зарегистрирован ли email: ошибки отправки только логируются.
+func (s *AuthServer) RequestPasswordReset(ctx context.Context, req *authv1.RequestPasswordResetRequest) (*authv1.RequestPasswordResetResponse, error) {
+	op := "RequestPasswordReset"
+	
+	slog.Info("password reset requested", slog.String("op", op), slog.String("email", req.Email))
+	
+	email, err := validator.NormalizeEmail(req.Email)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	
+	user, err := s.repo.GetUserByEmail(ctx, email)
+	if err == repo.ErrUserNotFound {
+		slog.Warn("user not found", slog.String("op", op), slog.String("email", email))
+		return &authv1.RequestPasswordResetResponse{Ok: true}, nil
+	}
+	if err != nil {
+		slog.Error("failed to get user", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	if err := s.sendPasswordReset(ctx, user.ID, user.Email); err != nil {
+		slog.Error("failed to send password reset email", slog.String("op", op), slog.String("user_id", user.ID), slog.Any("error", err))
+		return &authv1.RequestPasswordResetResponse{Ok: true}, nil
+	}
+	
+	slog.Info("password reset email sent", slog.String("op", op), slog.String("user_id", user.ID))
+	
+	return &authv1.RequestPasswordResetResponse{Ok: true}, nil
+}
+
+// ResetPassword устанавливает новый пароль по одноразовому токену из письма.
+// Все сессии пользователя завершаются: refresh токены отзываются, а выпущенные
+// ранее access токены попадают в denylist до истечения срока.
+func (s *AuthServer) ResetPassword(ctx context.Context, req *authv1.ResetPasswordRequest) (*authv1.ResetPasswordResponse, error) {
+	op := "ResetPassword"
+	
+	slog.Info("reset password", slog.String("op", op))
+	
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "password reset token required")
+	}
+	
+	// Email владельца токена до его погашения неизвестен, поэтому проверка без него
+	if err := s.checkPassword(op, "new_password", req.NewPassword, ""); err != nil {
+		return nil, err
+	}
+	
+	passHash, err := s.hasher.Hash(req.NewPassword)
+	if err != nil {
+		slog.Error("failed to hash password", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	userID, err := s.resets.ResetPassword(ctx, token.Hash(req.Token), passHash)
+	switch err {
+	case nil:
+	case repo.ErrPasswordResetTokenExpired:
+		slog.Warn("password reset token expired", slog.String("op", op))
+		return nil, status.Error(codes.InvalidArgument, "password reset token expired")
+	case repo.ErrPasswordResetTokenNotFound, repo.ErrPasswordResetTokenUsed:
+		slog.Warn("invalid password reset token", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.InvalidArgument, "invalid password reset token")
+	default:
+		slog.Error("failed to reset password", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	if err := s.revokeUserTokens(ctx, userID); err != nil {
+		slog.Error("failed to revoke access tokens", slog.String("op", op), slog.String("user_id", userID), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	slog.Info("password reset", slog.String("op", op), slog.String("user_id", userID))
+	
+	return &authv1.ResetPasswordResponse{Ok: true}, nil
+}
+
+// sendPasswordReset выпускает токен сброса пароля и отправляет его письмом
+func (s *AuthServer) sendPasswordReset(ctx context.Context, userID, email string) error {
+	resetToken, resetHash, err := token.NewOpaque()
+	if err != nil {
+		return err
+	}
+	
+	if err := s.resets.CreatePasswordResetToken(ctx, userID, resetHash, time.Now().Add(s.cfg.PasswordResetTokenTTL)); err != nil {
+		return err
+	}
+	
+	body := fmt.Sprintf("Для сброса пароля используйте токен:\n\n%s\n\nТокен действует %s.\n", resetToken, s.cfg.PasswordResetTokenTTL)
+	if link, ok := tokenLink(s.cfg.PasswordResetURL, resetToken); ok {
+		body = fmt.Sprintf("Для сброса пароля перейдите по ссылке:\n\n%s\n\nСсылка действует %s.\n", link, s.cfg.PasswordResetTokenTTL)
+	}
+	body += "Если вы не запрашивали сброс пароля, проигнорируйте это письмо.\n"
+	
+	return s.mailer.Send(ctx, domain.Email{
+		To:      email,
+		Subject: "Сброс пароля",
+		Body:    body,
+	})
+}
+
+// tokenLink добавляет токен параметром token к адресу страницы.
+// Возвращает false, если адрес не задан или некорректен.
+func tokenLink(baseURL, value string) (string, bool) {
+	if baseURL == "" {
+		return "", false
+	}
+	link, err := url.Parse(baseURL)
+	if err != nil {
+		return "", false
+	}
+	query := link.Query()
+	query.Set("token", value)
+	link.RawQuery = query.Encode()
+	return link.String(), true
+}
+
+// isRevoked проверяет denylist: отзыв конкретного токена (SignOut) и всех токенов
+// пользователя (сброс пароля)
+func (s *AuthServer) isRevoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	if claims.ID != "" {
+		revoked, err := s.revoked.IsRevoked(ctx, claims.ID)
+		if err != nil || revoked {
+			return revoked, err
+		}
+	}
+	if claims.IssuedAt != nil {
+		return s.revoked.IsUserRevoked(ctx, claims.UserID, claims.IssuedAt.Time)
+	}
+	return false, nil
+}
+
+// revokeUserTokens отзывает все access токены пользователя, выпущенные до текущей секунды.
+// iat в JWT хранится с точностью до секунды, поэтому граница округляется вниз: токены,
+// выпущенные сразу после отзыва (новый вход, refresh), остаются действительными, а
+// выпущенные в ту же секунду до отзыва - тоже, это цена секундной точности iat.
+func (s *AuthServer) revokeUserTokens(ctx context.Context, userID string) error {
+	now := time.Now().Truncate(time.Second)
+	return s.revoked.RevokeUser(ctx, userID, now, now.Add(s.jwt.TTL()))
+}
+
+// ChangePassword меняет пароль пользователя после проверки текущего.
+// Остальные сессии завершаются: их refresh токены и все выпущенные access токены
+// отзываются. Вызывающий получает новый access токен; сессия, чей refresh токен
+// передан в запросе, продолжает обновляться.
+func (s *AuthServer) ChangePassword(ctx context.Context, req *authv1.ChangePasswordRequest) (*authv1.ChangePasswordResponse, error) {
+	op := "ChangePassword"
+	
+	claims, err := s.authenticate(ctx, op, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	
+	slog.Info("change password", slog.String("op", op), slog.String("user_id", claims.UserID))
+	
+	user, err := s.verifyUserPassword(ctx, op, claims.UserID, req.CurrentPassword)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := s.checkPassword(op, "new_password", req.NewPassword, user.Email); err != nil {
+		return nil, err
+	}
+	
+	passHash, err := s.hasher.Hash(req.NewPassword)
+	if err != nil {
+		slog.Error("failed to hash password", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	err = s.repo.UpdatePassword(ctx, claims.UserID, passHash)
+	if err == repo.ErrUserNotFound {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		slog.Error("failed to update password", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	// Пароль мог утечь: сессии, открытые с ним, больше не продлеваются
+	var keepHash string
+	if req.RefreshToken != "" {
+		keepHash = token.Hash(req.RefreshToken)
+	}
+	if err := s.refreshTokens.RevokeUserRefreshTokens(ctx, claims.UserID, keepHash); err != nil {
+		slog.Error("failed to revoke refresh tokens", slog.String("op", op), slog.String("user_id", claims.UserID), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	// Access токены живут до суток: без отзыва по iat утёкший пароль давал бы доступ до их истечения
+	if err := s.revokeUserTokens(ctx, claims.UserID); err != nil {
+		slog.Error("failed to revoke user tokens", slog.String("op", op), slog.String("user_id", claims.UserID), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	// Токен запроса отозван вместе с остальными, вызывающему нужен новый
+	roles, err := s.roles.GetUserRoles(ctx, claims.UserID)
+	if err != nil {
+		slog.Error("failed to get user roles", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	accessToken, err := s.jwt.Sign(claims.UserID, s.signOptions(roles)...)
+	if err != nil {
+		slog.Error("failed to generate access token", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "failed to generate token")
+	}
+	
+	slog.Info("password changed", slog.String("op", op), slog.String("user_id", claims.UserID))
+	
+	return &authv1.ChangePasswordResponse{Ok: true, AccessToken: accessToken}, nil
+}
+
+// ChangeEmail запрашивает смену email после проверки пароля.
+// На новый адрес отправляется письмо подтверждения; email меняется только в VerifyEmail.
+func (s *AuthServer) ChangeEmail(ctx context.Context, req *authv1.ChangeEmailRequest) (*authv1.ChangeEmailResponse, error) {
+	op := "ChangeEmail"
+	
+	claims, err := s.authenticate(ctx, op, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	
+	slog.Info("change email", slog.String("op", op), slog.String("user_id", claims.UserID), slog.String("email", req.NewEmail))
+	
+	newEmail, err := validator.NormalizeEmail(req.NewEmail)
+	if err != nil {
+		slog.Warn("invalid email", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	
+	user, err := s.verifyUserPassword(ctx, op, claims.UserID, req.Password)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Адреса, отличающиеся только регистром, принадлежат одному ящику
+	if strings.EqualFold(newEmail, user.Email) {
+		return nil, status.Error(codes.InvalidArgument, "new email must differ from current")
+	}
+	
+	exists, err := s.repo.UserExistsByEmail(ctx, newEmail)
+	if err != nil {
+		slog.Error("failed to check user existence", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	if exists {
+		slog.Warn("email already taken", slog.String("op", op), slog.String("email", newEmail))
+		return nil, status.Error(codes.AlreadyExists, "user already exists")
+	}
+	
+	// До подтверждения вход и письма продолжают использовать текущий адрес
+	if err := s.sendVerification(ctx, claims.UserID, newEmail); err != nil {
+		slog.Error("failed to send verification email", slog.String("op", op), slog.String("user_id", claims.UserID), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	slog.Info("email change requested", slog.String("op", op), slog.String("user_id", claims.UserID))
+	
+	return &authv1.ChangeEmailResponse{Ok: true}, nil
+}
+
+// DeleteUser удаляет аккаунт пользователя после проверки пароля.
+// Аккаунт сразу перестаёт работать: сессии завершаются, токены отзываются.
+// Данные удаляются окончательно по истечении срока ожидания, после чего
+// публикуется событие UserDeleted для остальных сервисов.
+func (s *AuthServer) DeleteUser(ctx context.Context, req *authv1.DeleteUserRequest) (*authv1.DeleteUserResponse, error) {
+	op := "DeleteUser"
+	
+	claims, err := s.authenticate(ctx, op, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	
+	slog.Info("delete user", slog.String("op", op), slog.String("user_id", claims.UserID))
+	
+	if _, err := s.verifyUserPassword(ctx, op, claims.UserID, req.Password); err != nil {
+		return nil, err
+	}
+	
+	err = s.repo.SoftDeleteUser(ctx, claims.UserID)
+	if err == repo.ErrUserNotFound {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		slog.Error("failed to delete user", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	if err := s.revokeUserTokens(ctx, claims.UserID); err != nil {
+		slog.Error("failed to revoke access tokens", slog.String("op", op), slog.String("user_id", claims.UserID), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	slog.Info("user deleted", slog.String("op", op), slog.String("user_id", claims.UserID))
+	
+	return &authv1.DeleteUserResponse{Ok: true}, nil
+}
+
+// verifyUserPassword загружает пользователя и сверяет его пароль.
+// Возвращает ошибку gRPC, готовую для ответа клиенту.
+func (s *AuthServer) verifyUserPassword(ctx context.Context, op, userID, password string) (*domain.User, error) {
+	if password == "" {
+		return nil, status.Error(codes.InvalidArgument, "password required")
+	}
+	
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err == repo.ErrUserNotFound {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		slog.Error("failed to get user", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	valid, err := s.hasher.Verify(password, user.PassHash)
+	if err != nil {
+		slog.Error("failed to verify password", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	if !valid {
+		slog.Warn("invalid password", slog.String("op", op), slog.String("user_id", userID))
+		return nil, status.Error(codes.PermissionDenied, "invalid password")
+	}
+	
+	return user, nil
+}
+
+// signOptions возвращает claims выпускаемого access токена
+func (s *AuthServer) signOptions(roles []string) []jwt.SignOption {
+	opts := []jwt.SignOption{jwt.WithRoles(roles...)}
+	if s.cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(s.cfg.Audience))
+	}
+	return opts
+}
+
+// GrantRole выдаёт пользователю роль. Доступно только администраторам.
+func (s *AuthServer) GrantRole(ctx context.Context, req *authv1.GrantRoleRequest) (*authv1.GrantRoleResponse, error) {
+	op := "GrantRole"
+	
+	adminID, err := s.authorizeAdmin(ctx, op, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := validator.ValidateUserID(req.UserId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validator.ValidateRole(req.Role); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	
+	err = s.roles.GrantRole(ctx, req.UserId, req.Role, adminID)
+	if err == repo.ErrRoleNotFound {
+		return nil, status.Error(codes.NotFound, "role not found")
+	}
+	if err == repo.ErrUserNotFound {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		slog.Error("failed to grant role", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	slog.Info("role granted", slog.String("op", op), slog.String("user_id", req.UserId), slog.String("role", req.Role), slog.String("admin_id", adminID))
+	
+	roles, err := s.roles.GetUserRoles(ctx, req.UserId)
+	if err != nil {
+		slog.Error("failed to get user roles", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	return &authv1.GrantRoleResponse{Roles: roles}, nil
+}
+
+// RevokeRole отзывает роль у пользователя. Доступно только администраторам.
+func (s *AuthServer) RevokeRole(ctx context.Context, req *authv1.RevokeRoleRequest) (*authv1.RevokeRoleResponse, error) {
+	op := "RevokeRole"
+	
+	adminID, err := s.authorizeAdmin(ctx, op, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := validator.ValidateUserID(req.UserId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validator.ValidateRole(req.Role); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	
+	// Защита от потери последнего доступа к управлению ролями
+	if req.UserId == adminID && req.Role == domain.RoleAdmin {
+		return nil, status.Error(codes.FailedPrecondition, "cannot revoke own admin role")
+	}
+	
+	if _, err := s.repo.GetUserByID(ctx, req.UserId); err != nil {
+		if err == repo.ErrUserNotFound {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		slog.Error("failed to get user", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	if err := s.roles.RevokeRole(ctx, req.UserId, req.Role); err != nil {
+		slog.Error("failed to revoke role", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	slog.Info("role revoked", slog.String("op", op), slog.String("user_id", req.UserId), slog.String("role", req.Role), slog.String("admin_id", adminID))
+	
+	roles, err := s.roles.GetUserRoles(ctx, req.UserId)
+	if err != nil {
+		slog.Error("failed to get user roles", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	return &authv1.RevokeRoleResponse{Roles: roles}, nil
+}
+
+// authorizeAdmin проверяет, что токен принадлежит администратору, и возвращает его ID.
+// Роль сверяется с БД, а не с claims: отозванная роль перестаёт действовать сразу,
+// не дожидаясь истечения токена.
+func (s *AuthServer) authorizeAdmin(ctx context.Context, op, accessToken string) (string, error) {
+	claims, err := s.authenticate(ctx, op, accessToken)
+	if err != nil {
+		return "", err
+	}
+	
+	roles, err := s.roles.GetUserRoles(ctx, claims.UserID)
+	if err != nil {
+		slog.Error("failed to get user roles", slog.String("op", op), slog.Any("error", err))
+		return "", status.Error(codes.Internal, "internal error")
+	}
+	for _, role := range roles {
+		if role == domain.RoleAdmin {
+			return claims.UserID, nil
+		}
+	}
+	
+	slog.Warn("admin role required", slog.String("op", op), slog.String("user_id", claims.UserID))
+	return "", status.Error(codes.PermissionDenied, "admin role required")
+}
+
+// authenticate проверяет access токен, включая denylist, и возвращает его claims
+func (s *AuthServer) authenticate(ctx context.Context, op, accessToken string) (*jwt.Claims, error) {
+	if accessToken == "" {
+		return nil, status.Error(codes.Unauthenticated, "access token required")
+	}
+	
+	claims, err := s.jwt.Validate(accessToken)
+	if err != nil {
+		if errors.Is(err, jwt.ErrExpiredToken) || errors.Is(err, jwt.ErrInvalidToken) {
+			slog.Warn("invalid token", slog.String("op", op), slog.Any("error", err))
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		slog.Error("failed to validate token", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	
+	revoked, err := s.isRevoked(ctx, claims)
+	if err != nil {
+		slog.Error("failed to check token revocation", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	if revoked {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	
+	return claims, nil
+}
\ No newline at end of file
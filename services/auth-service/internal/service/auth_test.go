@@ -0,0 +1,958 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authv1 "golang-project/api/proto/gen/go/auth/v1"
+	"golang-project/pkg/auth/jwt"
+	"golang-project/services/auth-service/internal/authtest"
+	"golang-project/services/auth-service/internal/revocation"
+	"golang-project/services/auth-service/internal/throttle"
+	"golang-project/services/auth-service/internal/token"
+	"golang-project/services/auth-service/internal/totp"
+	"golang-project/services/auth-service/internal/validator"
+)
+
+const (
+	testEmail    = "alice@example.com"
+	testPassword = "Vq8#mTz2!pLr"
+)
+
+// testEnv сервис на in-memory зависимостях
+type testEnv struct {
+	server  *AuthServer
+	store   *authtest.Store
+	hasher  *authtest.Hasher
+	mailer  *authtest.Mailer
+	revoked *revocation.MemoryStore
+	limiter *throttle.Limiter
+	jwtCfg  jwt.Config
+}
+
+func newTestEnv(t *testing.T, cfg Config) *testEnv {
+	t.Helper()
+
+	jwtCfg, err := authtest.NewJWTConfig()
+	if err != nil {
+		t.Fatalf("NewJWTConfig() error = %v", err)
+	}
+	jwtManager, err := jwt.NewManager(jwtCfg)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	if cfg.TOTPCipher == nil {
+		cfg.TOTPCipher, err = totp.NewSecretCipher(make([]byte, totp.KeySize))
+		if err != nil {
+			t.Fatalf("NewSecretCipher() error = %v", err)
+		}
+	}
+
+	env := &testEnv{
+		store:   authtest.NewStore(),
+		hasher:  authtest.NewHasher(),
+		mailer:  authtest.NewMailer(),
+		revoked: revocation.NewMemoryStore(),
+		limiter: throttle.NewLimiter(throttle.NewMemoryStore(), throttle.Config{}),
+		jwtCfg:  jwtCfg,
+	}
+	env.server = NewAuthServer(env.store, env.store, env.store, env.store, env.store, env.store, env.revoked, env.limiter, env.mailer, env.hasher, jwtManager, cfg)
+	return env
+}
+
+// createUser создаёт пользователя с паролем testPassword
+func (env *testEnv) createUser(t *testing.T, email string, verified bool) string {
+	t.Helper()
+
+	passHash, err := env.hasher.Hash(testPassword)
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	return env.createUserWithHash(t, email, passHash, verified)
+}
+
+func (env *testEnv) createUserWithHash(t *testing.T, email, passHash string, verified bool) string {
+	t.Helper()
+
+	userID, err := env.store.CreateUser(context.Background(), email, passHash)
+	if err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+	if verified {
+		if err := env.store.VerifyEmail(userID); err != nil {
+			t.Fatalf("VerifyEmail() error = %v", err)
+		}
+	}
+	return userID
+}
+
+func TestAuthServer_SignUp(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           Config
+		setup         func(t *testing.T, env *testEnv)
+		email         string
+		password      string
+		wantCode      codes.Code
+		wantViolation string
+		wantUserID    bool
+		wantPending   bool
+		wantMail      string
+	}{
+		{
+			name:     "invalid email",
+			email:    "not-an-email",
+			password: testPassword,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:          "weak password",
+			email:         testEmail,
+			password:      "password",
+			wantCode:      codes.InvalidArgument,
+			wantViolation: validator.ViolationCommon,
+		},
+		{
+			name:          "password too short",
+			email:         testEmail,
+			password:      "aB3$",
+			wantCode:      codes.InvalidArgument,
+			wantViolation: validator.ViolationTooShort,
+		},
+		{
+			name:  "email taken",
+			email: testEmail,
+			setup: func(t *testing.T, env *testEnv) {
+				env.createUser(t, testEmail, true)
+			},
+			password: testPassword,
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name:  "email taken in other case",
+			email: "Alice@EXAMPLE.com",
+			setup: func(t *testing.T, env *testEnv) {
+				env.createUser(t, testEmail, true)
+			},
+			password: testPassword,
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name:  "email taken by deleted user",
+			email: testEmail,
+			setup: func(t *testing.T, env *testEnv) {
+				userID := env.createUser(t, testEmail, true)
+				if err := env.store.SoftDeleteUser(context.Background(), userID); err != nil {
+					t.Fatalf("SoftDeleteUser() error = %v", err)
+				}
+			},
+			password: testPassword,
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name:  "email taken, enumeration resistant",
+			cfg:   Config{EnumerationResistant: true},
+			email: testEmail,
+			setup: func(t *testing.T, env *testEnv) {
+				env.createUser(t, testEmail, true)
+			},
+			password:    testPassword,
+			wantPending: true,
+			wantMail:    "Попытка регистрации",
+		},
+		{
+			name:       "success",
+			email:      testEmail,
+			password:   testPassword,
+			wantUserID: true,
+			wantMail:   "Подтверждение email",
+		},
+		{
+			name:        "success, enumeration resistant",
+			cfg:         Config{EnumerationResistant: true},
+			email:       testEmail,
+			password:    testPassword,
+			wantPending: true,
+			wantMail:    "Подтверждение email",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t, tt.cfg)
+			if tt.setup != nil {
+				tt.setup(t, env)
+			}
+
+			resp, err := env.server.SignUp(context.Background(), &authv1.SignUpRequest{
+				Email:    tt.email,
+				Password: tt.password,
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("SignUp() code = %v, want %v (error = %v)", code, tt.wantCode, err)
+			}
+			if tt.wantViolation != "" && !hasViolation(err, tt.wantViolation) {
+				t.Errorf("SignUp() error = %v, want violation %q", err, tt.wantViolation)
+			}
+			if err != nil {
+				if sent := env.mailer.Sent(); len(sent) != 0 {
+					t.Errorf("SignUp() sent %d emails on error", len(sent))
+				}
+				return
+			}
+
+			if got := resp.UserId != ""; got != tt.wantUserID {
+				t.Errorf("SignUp() UserId = %q, want set = %v", resp.UserId, tt.wantUserID)
+			}
+			if resp.VerificationPending != tt.wantPending {
+				t.Errorf("SignUp() VerificationPending = %v, want %v", resp.VerificationPending, tt.wantPending)
+			}
+			if resp.UserId != "" {
+				passHash, ok := env.store.PassHash(resp.UserId)
+				if !ok {
+					t.Fatalf("user %s not stored", resp.UserId)
+				}
+				if valid, _ := env.hasher.Verify(tt.password, passHash); !valid {
+					t.Errorf("stored hash does not match password")
+				}
+			}
+
+			sent := env.mailer.Sent()
+			if len(sent) != 1 {
+				t.Fatalf("SignUp() sent %d emails, want 1", len(sent))
+			}
+			if sent[0].To != tt.email || sent[0].Subject != tt.wantMail {
+				t.Errorf("SignUp() sent %q to %s, want %q to %s", sent[0].Subject, sent[0].To, tt.wantMail, tt.email)
+			}
+		})
+	}
+}
+
+func TestAuthServer_SignUp_Concurrent(t *testing.T) {
+	env := newTestEnv(t, Config{})
+
+	const workers = 10
+	var (
+		wg      sync.WaitGroup
+		start   = make(chan struct{})
+		results = make(chan codes.Code, workers)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := env.server.SignUp(context.Background(), &authv1.SignUpRequest{
+				Email:    testEmail,
+				Password: testPassword,
+			})
+			results <- status.Code(err)
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(results)
+
+	counts := make(map[codes.Code]int)
+	for code := range results {
+		counts[code]++
+	}
+	if counts[codes.OK] != 1 || counts[codes.AlreadyExists] != workers-1 {
+		t.Errorf("SignUp() codes = %v, want 1 OK and %d AlreadyExists", counts, workers-1)
+	}
+}
+
+func TestAuthServer_SignIn(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      Config
+		setup    func(t *testing.T, env *testEnv)
+		email    string
+		password string
+		wantCode codes.Code
+		wantMFA  bool
+		// check дополнительная проверка состояния после входа
+		check func(t *testing.T, env *testEnv)
+	}{
+		{
+			name:     "invalid email",
+			email:    "not-an-email",
+			password: testPassword,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "empty password",
+			email:    testEmail,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "unknown user",
+			email:    testEmail,
+			password: testPassword,
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "unknown user, enumeration resistant",
+			cfg:      Config{EnumerationResistant: true},
+			email:    testEmail,
+			password: testPassword,
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:  "deleted user",
+			email: testEmail,
+			setup: func(t *testing.T, env *testEnv) {
+				userID := env.createUser(t, testEmail, true)
+				if err := env.store.SoftDeleteUser(context.Background(), userID); err != nil {
+					t.Fatalf("SoftDeleteUser() error = %v", err)
+				}
+			},
+			password: testPassword,
+			wantCode: codes.NotFound,
+		},
+		{
+			name:  "wrong password",
+			email: testEmail,
+			setup: func(t *testing.T, env *testEnv) {
+				env.createUser(t, testEmail, true)
+			},
+			password: "wrong-password",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:  "wrong password, enumeration resistant",
+			cfg:   Config{EnumerationResistant: true},
+			email: testEmail,
+			setup: func(t *testing.T, env *testEnv) {
+				env.createUser(t, testEmail, true)
+			},
+			password: "wrong-password",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:  "unverified email allowed",
+			email: testEmail,
+			setup: func(t *testing.T, env *testEnv) {
+				env.createUser(t, testEmail, false)
+			},
+			password: testPassword,
+			wantCode: codes.OK,
+		},
+		{
+			name:  "unverified email required",
+			cfg:   Config{RequireEmailVerification: true},
+			email: testEmail,
+			setup: func(t *testing.T, env *testEnv) {
+				env.createUser(t, testEmail, false)
+			},
+			password: testPassword,
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:  "unverified email, enumeration resistant",
+			cfg:   Config{EnumerationResistant: true},
+			email: testEmail,
+			setup: func(t *testing.T, env *testEnv) {
+				env.createUser(t, testEmail, false)
+			},
+			password: testPassword,
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:  "throttled",
+			email: testEmail,
+			setup: func(t *testing.T, env *testEnv) {
+				env.createUser(t, testEmail, true)
+				for i := 0; i < 5; i++ {
+					if err := env.limiter.Fail(context.Background(), testEmail, ""); err != nil {
+						t.Fatalf("Fail() error = %v", err)
+					}
+				}
+			},
+			password: testPassword,
+			wantCode: codes.ResourceExhausted,
+		},
+		{
+			name:  "success",
+			email: testEmail,
+			setup: func(t *testing.T, env *testEnv) {
+				env.createUser(t, testEmail, true)
+			},
+			password: testPassword,
+			wantCode: codes.OK,
+		},
+		{
+			name:  "success with email in other case",
+			email: " ALICE@Example.com ",
+			setup: func(t *testing.T, env *testEnv) {
+				env.createUser(t, testEmail, true)
+			},
+			password: testPassword,
+			wantCode: codes.OK,
+		},
+		{
+			name:  "success rehashes legacy hash",
+			email: testEmail,
+			setup: func(t *testing.T, env *testEnv) {
+				env.createUserWithHash(t, testEmail, authtest.LegacyHash(testPassword), true)
+			},
+			password: testPassword,
+			wantCode: codes.OK,
+			check: func(t *testing.T, env *testEnv) {
+				user, err := env.store.GetUserByEmail(context.Background(), testEmail)
+				if err != nil {
+					t.Fatalf("GetUserByEmail() error = %v", err)
+				}
+				if env.hasher.NeedsRehash(user.PassHash) {
+					t.Errorf("hash %q not rehashed", user.PassHash)
+				}
+				if valid, _ := env.hasher.Verify(testPassword, user.PassHash); !valid {
+					t.Errorf("rehashed hash does not match password")
+				}
+			},
+		},
+		{
+			name:  "mfa required",
+			email: testEmail,
+			setup: func(t *testing.T, env *testEnv) {
+				ctx := context.Background()
+				userID := env.createUser(t, testEmail, true)
+				if err := env.store.SetPendingTOTP(ctx, userID, "JBSWY3DPEHPK3PXP"); err != nil {
+					t.Fatalf("SetPendingTOTP() error = %v", err)
+				}
+				if err := env.store.ConfirmTOTP(ctx, userID, 1, nil); err != nil {
+					t.Fatalf("ConfirmTOTP() error = %v", err)
+				}
+			},
+			password: testPassword,
+			wantCode: codes.OK,
+			wantMFA:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t, tt.cfg)
+			if tt.setup != nil {
+				tt.setup(t, env)
+			}
+
+			resp, err := env.server.SignIn(context.Background(), &authv1.SignInRequest{
+				Email:    tt.email,
+				Password: tt.password,
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("SignIn() code = %v, want %v (error = %v)", code, tt.wantCode, err)
+			}
+			// В режиме EnumerationResistant все отказы неотличимы
+			if tt.cfg.EnumerationResistant && err != nil && err != errInvalidCredentials {
+				t.Errorf("SignIn() error = %v, want %v", err, errInvalidCredentials)
+			}
+			if err != nil {
+				return
+			}
+
+			if resp.MfaRequired != tt.wantMFA {
+				t.Errorf("SignIn() MfaRequired = %v, want %v", resp.MfaRequired, tt.wantMFA)
+			}
+			if tt.wantMFA {
+				if resp.MfaToken == "" || resp.AccessToken != "" || resp.RefreshToken != "" {
+					t.Errorf("SignIn() = %+v, want only mfa token", resp)
+				}
+			} else {
+				if resp.AccessToken == "" || resp.RefreshToken == "" {
+					t.Errorf("SignIn() = %+v, want access and refresh tokens", resp)
+				}
+				validated, err := env.server.ValidateToken(context.Background(), &authv1.ValidateTokenRequest{Token: resp.AccessToken})
+				if err != nil || !validated.Valid {
+					t.Errorf("ValidateToken() = %v, %v, want valid access token", validated, err)
+				}
+			}
+			if tt.check != nil {
+				tt.check(t, env)
+			}
+		})
+	}
+}
+
+func TestAuthServer_ValidateToken(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		cfg  Config
+		// token выпускает токен для пользователя userID
+		token     func(t *testing.T, env *testEnv, userID string) string
+		audience  string
+		wantCode  codes.Code
+		wantValid bool
+		wantRoles []string
+	}{
+		{
+			name:     "empty token",
+			token:    func(t *testing.T, env *testEnv, userID string) string { return "" },
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:  "malformed token",
+			token: func(t *testing.T, env *testEnv, userID string) string { return "not-a-jwt" },
+		},
+		{
+			name: "foreign key",
+			token: func(t *testing.T, env *testEnv, userID string) string {
+				cfg, err := authtest.NewJWTConfig()
+				if err != nil {
+					t.Fatalf("NewJWTConfig() error = %v", err)
+				}
+				return sign(t, cfg, userID)
+			},
+		},
+		{
+			name: "expired",
+			token: func(t *testing.T, env *testEnv, userID string) string {
+				cfg := env.jwtCfg
+				cfg.TTL = -time.Minute
+				return sign(t, cfg, userID)
+			},
+		},
+		{
+			name:      "valid",
+			token:     signIn,
+			wantValid: true,
+			wantRoles: []string{"admin", "editor"},
+		},
+		{
+			name:      "audience matches",
+			cfg:       Config{Audience: "notes-service"},
+			token:     signIn,
+			audience:  "notes-service",
+			wantValid: true,
+			wantRoles: []string{"admin", "editor"},
+		},
+		{
+			name:     "audience mismatch",
+			cfg:      Config{Audience: "notes-service"},
+			token:    signIn,
+			audience: "billing-service",
+		},
+		{
+			name: "revoked token",
+			token: func(t *testing.T, env *testEnv, userID string) string {
+				accessToken := signIn(t, env, userID)
+				claims, err := env.server.jwt.Validate(accessToken)
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				if err := env.revoked.Revoke(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+					t.Fatalf("Revoke() error = %v", err)
+				}
+				return accessToken
+			},
+		},
+		{
+			name: "user tokens revoked",
+			token: func(t *testing.T, env *testEnv, userID string) string {
+				accessToken := signIn(t, env, userID)
+				waitNextSecond()
+				if err := env.server.revokeUserTokens(ctx, userID); err != nil {
+					t.Fatalf("revokeUserTokens() error = %v", err)
+				}
+				return accessToken
+			},
+		},
+		{
+			name: "signed in right after user tokens revoked",
+			token: func(t *testing.T, env *testEnv, userID string) string {
+				if err := env.server.revokeUserTokens(ctx, userID); err != nil {
+					t.Fatalf("revokeUserTokens() error = %v", err)
+				}
+				// Та же секунда, что и отзыв: токен не должен попасть под него
+				return signIn(t, env, userID)
+			},
+			wantValid: true,
+			wantRoles: []string{"admin", "editor"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t, tt.cfg)
+			userID := env.createUser(t, testEmail, true)
+			for _, role := range []string{"editor", "admin"} {
+				if err := env.store.GrantRole(ctx, userID, role, ""); err != nil {
+					t.Fatalf("GrantRole() error = %v", err)
+				}
+			}
+
+			resp, err := env.server.ValidateToken(ctx, &authv1.ValidateTokenRequest{
+				Token:    tt.token(t, env, userID),
+				Audience: tt.audience,
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("ValidateToken() code = %v, want %v (error = %v)", code, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if resp.Valid != tt.wantValid {
+				t.Fatalf("ValidateToken() Valid = %v, want %v", resp.Valid, tt.wantValid)
+			}
+			if !tt.wantValid {
+				if resp.UserId != "" {
+					t.Errorf("ValidateToken() UserId = %q for invalid token", resp.UserId)
+				}
+				return
+			}
+			if resp.UserId != userID {
+				t.Errorf("ValidateToken() UserId = %q, want %q", resp.UserId, userID)
+			}
+			if !equalStrings(resp.Roles, tt.wantRoles) {
+				t.Errorf("ValidateToken() Roles = %v, want %v", resp.Roles, tt.wantRoles)
+			}
+		})
+	}
+}
+
+func TestAuthServer_RefreshToken(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// ttl срок refresh токена, выданного при входе
+		ttl time.Duration
+		// replay предъявляет исходный токен повторно, после его успешной ротации
+		replay   bool
+		unknown  bool
+		wantCode codes.Code
+	}{
+		{name: "rotate", ttl: time.Hour, wantCode: codes.OK},
+		{name: "replay rotated token", ttl: time.Hour, replay: true, wantCode: codes.Unauthenticated},
+		{name: "expired", ttl: -time.Minute, wantCode: codes.Unauthenticated},
+		{name: "unknown", ttl: time.Hour, unknown: true, wantCode: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t, Config{RefreshTokenTTL: tt.ttl})
+			userID := env.createUser(t, testEmail, true)
+
+			signedIn, err := env.server.SignIn(ctx, &authv1.SignInRequest{Email: testEmail, Password: testPassword})
+			if err != nil {
+				t.Fatalf("SignIn() error = %v", err)
+			}
+			presented := signedIn.RefreshToken
+			if tt.unknown {
+				presented = "unknown-refresh-token"
+			}
+
+			// rotated - токен того же семейства, выданный при честной ротации
+			var rotated *authv1.RefreshTokenResponse
+			if tt.replay {
+				rotated, err = env.server.RefreshToken(ctx, &authv1.RefreshTokenRequest{RefreshToken: presented})
+				if err != nil {
+					t.Fatalf("RefreshToken() first use error = %v", err)
+				}
+			}
+
+			resp, err := env.server.RefreshToken(ctx, &authv1.RefreshTokenRequest{RefreshToken: presented})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("RefreshToken() code = %v, want %v (err = %v)", code, tt.wantCode, err)
+			}
+
+			if tt.wantCode == codes.OK {
+				if resp.RefreshToken == "" || resp.RefreshToken == signedIn.RefreshToken {
+					t.Errorf("RefreshToken() refresh token = %q, want a new one", resp.RefreshToken)
+				}
+				validated, err := env.server.ValidateToken(ctx, &authv1.ValidateTokenRequest{Token: resp.AccessToken})
+				if err != nil {
+					t.Fatalf("ValidateToken() error = %v", err)
+				}
+				if !validated.Valid || validated.UserId != userID {
+					t.Errorf("ValidateToken() = %v for %q, want valid for %s", validated.Valid, validated.UserId, userID)
+				}
+				// Новый токен продолжает то же семейство и сам принимается
+				if _, err := env.server.RefreshToken(ctx, &authv1.RefreshTokenRequest{RefreshToken: resp.RefreshToken}); err != nil {
+					t.Errorf("RefreshToken() with rotated token error = %v", err)
+				}
+				return
+			}
+
+			// Повтор уже использованного токена - признак кражи: семейство отзывается целиком
+			if tt.replay {
+				if !env.store.RefreshTokenRevoked(token.Hash(rotated.RefreshToken)) {
+					t.Error("refresh token issued by rotation is not revoked after replay")
+				}
+				_, err := env.server.RefreshToken(ctx, &authv1.RefreshTokenRequest{RefreshToken: rotated.RefreshToken})
+				if code := status.Code(err); code != codes.Unauthenticated {
+					t.Errorf("RefreshToken() with revoked family token code = %v, want Unauthenticated", code)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthServer_ResetPassword_RevokesTokens(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, Config{PasswordResetTokenTTL: time.Hour})
+	userID := env.createUser(t, testEmail, true)
+	oldToken := signIn(t, env, userID)
+	waitNextSecond()
+
+	resetToken, resetHash, err := token.NewOpaque()
+	if err != nil {
+		t.Fatalf("NewOpaque() error = %v", err)
+	}
+	if err := env.store.CreatePasswordResetToken(ctx, userID, resetHash, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("CreatePasswordResetToken() error = %v", err)
+	}
+	const newPassword = "Hw4!rKp9#xQz"
+	if _, err := env.server.ResetPassword(ctx, &authv1.ResetPasswordRequest{Token: resetToken, NewPassword: newPassword}); err != nil {
+		t.Fatalf("ResetPassword() error = %v", err)
+	}
+
+	// Вход сразу после сброса, обычно в ту же секунду
+	resp, err := env.server.SignIn(ctx, &authv1.SignInRequest{Email: testEmail, Password: newPassword})
+	if err != nil {
+		t.Fatalf("SignIn() after reset error = %v", err)
+	}
+
+	for _, tt := range []struct {
+		name      string
+		token     string
+		wantValid bool
+	}{
+		{name: "issued before reset", token: oldToken, wantValid: false},
+		{name: "issued right after reset", token: resp.AccessToken, wantValid: true},
+	} {
+		validated, err := env.server.ValidateToken(ctx, &authv1.ValidateTokenRequest{Token: tt.token})
+		if err != nil {
+			t.Fatalf("ValidateToken() %s error = %v", tt.name, err)
+		}
+		if validated.Valid != tt.wantValid {
+			t.Errorf("ValidateToken() %s Valid = %v, want %v", tt.name, validated.Valid, tt.wantValid)
+		}
+	}
+}
+
+func TestAuthServer_ChangePassword_RevokesOtherSessions(t *testing.T) {
+	ctx := context.Background()
+	const newPassword = "Hw4!rKp9#xQz"
+
+	tests := []struct {
+		name string
+		// keepCurrent передать refresh токен текущей сессии
+		keepCurrent bool
+	}{
+		{name: "current session kept", keepCurrent: true},
+		{name: "no refresh token", keepCurrent: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t, Config{RefreshTokenTTL: time.Hour})
+			env.createUser(t, testEmail, true)
+
+			var sessions []*authv1.SignInResponse
+			for i := 0; i < 2; i++ {
+				resp, err := env.server.SignIn(ctx, &authv1.SignInRequest{Email: testEmail, Password: testPassword})
+				if err != nil {
+					t.Fatalf("SignIn() error = %v", err)
+				}
+				sessions = append(sessions, resp)
+			}
+			current, other := sessions[0], sessions[1]
+			waitNextSecond()
+
+			req := &authv1.ChangePasswordRequest{
+				AccessToken:     current.AccessToken,
+				CurrentPassword: testPassword,
+				NewPassword:     newPassword,
+			}
+			if tt.keepCurrent {
+				req.RefreshToken = current.RefreshToken
+			}
+			changed, err := env.server.ChangePassword(ctx, req)
+			if err != nil {
+				t.Fatalf("ChangePassword() error = %v", err)
+			}
+
+			if !env.store.RefreshTokenRevoked(token.Hash(other.RefreshToken)) {
+				t.Error("refresh token of other session is not revoked")
+			}
+			if got := env.store.RefreshTokenRevoked(token.Hash(current.RefreshToken)); got == tt.keepCurrent {
+				t.Errorf("refresh token of current session revoked = %v, want %v", got, !tt.keepCurrent)
+			}
+
+			_, err = env.server.RefreshToken(ctx, &authv1.RefreshTokenRequest{RefreshToken: other.RefreshToken})
+			if code := status.Code(err); code != codes.Unauthenticated {
+				t.Errorf("RefreshToken() of other session code = %v, want Unauthenticated", code)
+			}
+
+			// Access токены, выпущенные до смены пароля, отозваны; вызывающий получил новый
+			for _, tc := range []struct {
+				name      string
+				token     string
+				wantValid bool
+			}{
+				{name: "other session", token: other.AccessToken, wantValid: false},
+				{name: "current session", token: current.AccessToken, wantValid: false},
+				{name: "issued by ChangePassword", token: changed.AccessToken, wantValid: true},
+			} {
+				validated, err := env.server.ValidateToken(ctx, &authv1.ValidateTokenRequest{Token: tc.token})
+				if err != nil {
+					t.Fatalf("ValidateToken() %s error = %v", tc.name, err)
+				}
+				if validated.Valid != tc.wantValid {
+					t.Errorf("ValidateToken() %s Valid = %v, want %v", tc.name, validated.Valid, tc.wantValid)
+				}
+			}
+			// Методы, требующие access токен, отклоняют отозванный токен другой сессии
+			_, err = env.server.EnrollTOTP(ctx, &authv1.EnrollTOTPRequest{AccessToken: other.AccessToken, Password: newPassword})
+			if code := status.Code(err); code != codes.Unauthenticated {
+				t.Errorf("EnrollTOTP() with other session token code = %v, want Unauthenticated", code)
+			}
+		})
+	}
+}
+
+func TestAuthServer_ChangeEmail_PendingUntilVerified(t *testing.T) {
+	ctx := context.Background()
+	const newEmail = "alice.new@example.com"
+
+	tests := []struct {
+		name string
+		// takenBy регистрирует новый адрес на другого пользователя до подтверждения
+		takenBy  bool
+		wantCode codes.Code
+		wantMail string
+	}{
+		{name: "verified", wantCode: codes.OK, wantMail: newEmail},
+		{name: "taken before verification", takenBy: true, wantCode: codes.AlreadyExists, wantMail: testEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t, Config{VerificationTokenTTL: time.Hour})
+			userID := env.createUser(t, testEmail, true)
+
+			_, err := env.server.ChangeEmail(ctx, &authv1.ChangeEmailRequest{
+				AccessToken: signIn(t, env, userID),
+				Password:    testPassword,
+				NewEmail:    newEmail,
+			})
+			if err != nil {
+				t.Fatalf("ChangeEmail() error = %v", err)
+			}
+
+			// До подтверждения аккаунт остаётся на прежнем подтверждённом адресе
+			user, err := env.store.GetUserByID(ctx, userID)
+			if err != nil {
+				t.Fatalf("GetUserByID() error = %v", err)
+			}
+			if user.Email != testEmail || user.EmailVerifiedAt == nil {
+				t.Errorf("email before verification = %s (verified %v), want %s (verified)", user.Email, user.EmailVerifiedAt != nil, testEmail)
+			}
+			if _, err := env.server.SignIn(ctx, &authv1.SignInRequest{Email: newEmail, Password: testPassword}); err == nil {
+				t.Error("SignIn() with unverified new email succeeded")
+			}
+
+			sent := env.mailer.Sent()
+			if len(sent) != 1 || sent[0].To != newEmail {
+				t.Fatalf("ChangeEmail() sent %d emails, want 1 to %s", len(sent), newEmail)
+			}
+			// Без VerificationURL токен идёт отдельным абзацем письма
+			parts := strings.Split(sent[0].Body, "\n\n")
+			if len(parts) < 2 {
+				t.Fatalf("verification token not found in %q", sent[0].Body)
+			}
+
+			if tt.takenBy {
+				env.createUser(t, newEmail, false)
+			}
+
+			_, err = env.server.VerifyEmail(ctx, &authv1.VerifyEmailRequest{Token: parts[1]})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("VerifyEmail() code = %v, want %v (err = %v)", code, tt.wantCode, err)
+			}
+
+			user, err = env.store.GetUserByID(ctx, userID)
+			if err != nil {
+				t.Fatalf("GetUserByID() error = %v", err)
+			}
+			if user.Email != tt.wantMail || user.EmailVerifiedAt == nil {
+				t.Errorf("email after verification = %s (verified %v), want %s (verified)", user.Email, user.EmailVerifiedAt != nil, tt.wantMail)
+			}
+		})
+	}
+}
+
+// waitNextSecond ждёт начала следующей секунды. iat хранится с точностью до секунды,
+// и отзыв по iat не задевает токены, выпущенные в секунду отзыва
+func waitNextSecond() {
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+}
+
+// signIn выпускает access токен через SignIn пользователя testEmail
+func signIn(t *testing.T, env *testEnv, userID string) string {
+	t.Helper()
+
+	resp, err := env.server.SignIn(context.Background(), &authv1.SignInRequest{
+		Email:    testEmail,
+		Password: testPassword,
+	})
+	if err != nil {
+		t.Fatalf("SignIn() error = %v", err)
+	}
+	return resp.AccessToken
+}
+
+// sign выпускает токен менеджером с конфигурацией cfg
+func sign(t *testing.T, cfg jwt.Config, userID string) string {
+	t.Helper()
+
+	manager, err := jwt.NewManager(cfg)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	accessToken, err := manager.Sign(userID)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	return accessToken
+}
+
+// hasViolation проверяет, что ошибка содержит нарушение политики паролей с кодом reason
+func hasViolation(err error, reason string) bool {
+	for _, detail := range status.Convert(err).Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, v := range badRequest.FieldViolations {
+			if v.Field == "password" && v.Reason == reason {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authv1 "golang-project/api/proto/gen/go/auth/v1"
+	"golang-project/pkg/clientip"
+	"golang-project/services/auth-service/internal/domain"
+	"golang-project/services/auth-service/internal/repo"
+	"golang-project/services/auth-service/internal/token"
+	"golang-project/services/auth-service/internal/totp"
+)
+
+// EnrollTOTP начинает подключение приложения-аутентификатора: выдаёт новый секрет
+// и otpauth:// ссылку для QR-кода. Второй фактор включается только после ConfirmTOTP.
+func (s *AuthServer) EnrollTOTP(ctx context.Context, req *authv1.EnrollTOTPRequest) (*authv1.EnrollTOTPResponse, error) {
+	op := "EnrollTOTP"
+
+	if s.cfg.TOTPCipher == nil {
+		return nil, status.Error(codes.FailedPrecondition, "mfa is not configured")
+	}
+
+	claims, err := s.authenticate(ctx, op, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.verifyUserPassword(ctx, op, claims.UserID, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		slog.Error("failed to generate totp secret", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	// В БД секрет хранится только зашифрованным: утечка дампа не раскрывает второй фактор
+	sealed, err := s.cfg.TOTPCipher.Seal(user.ID, secret)
+	if err != nil {
+		slog.Error("failed to encrypt totp secret", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	err = s.mfa.SetPendingTOTP(ctx, user.ID, sealed)
+	if errors.Is(err, repo.ErrTOTPAlreadyConfirmed) {
+		return nil, status.Error(codes.FailedPrecondition, "mfa already enabled")
+	}
+	if err != nil {
+		slog.Error("failed to store totp secret", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	slog.Info("totp enrollment started", slog.String("op", op), slog.String("user_id", user.ID))
+
+	return &authv1.EnrollTOTPResponse{
+		Secret:     secret,
+		OtpauthUri: totp.URI(s.cfg.MFAIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTOTP включает второй фактор после проверки кода из приложения и выдаёт
+// коды восстановления. Коды показываются один раз: в БД хранятся только их хеши.
+func (s *AuthServer) ConfirmTOTP(ctx context.Context, req *authv1.ConfirmTOTPRequest) (*authv1.ConfirmTOTPResponse, error) {
+	op := "ConfirmTOTP"
+
+	claims, err := s.authenticate(ctx, op, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := s.mfa.GetTOTP(ctx, claims.UserID)
+	if errors.Is(err, repo.ErrTOTPNotFound) {
+		return nil, status.Error(codes.FailedPrecondition, "totp enrollment not started")
+	}
+	if err != nil {
+		slog.Error("failed to get user totp", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	if secret.ConfirmedAt != nil {
+		return nil, status.Error(codes.FailedPrecondition, "mfa already enabled")
+	}
+
+	plain, err := s.openTOTPSecret(op, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	step, ok, err := totp.Validate(plain, strings.TrimSpace(req.Code), time.Now())
+	if err != nil {
+		slog.Error("failed to validate totp code", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	if !ok {
+		slog.Warn("invalid totp code", slog.String("op", op), slog.String("user_id", claims.UserID))
+		return nil, status.Error(codes.InvalidArgument, "invalid code")
+	}
+
+	recoveryCodes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, hash, err := token.NewRecoveryCode()
+		if err != nil {
+			slog.Error("failed to generate recovery code", slog.String("op", op), slog.Any("error", err))
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+		recoveryCodes = append(recoveryCodes, code)
+		hashes = append(hashes, hash)
+	}
+
+	err = s.mfa.ConfirmTOTP(ctx, claims.UserID, step, hashes)
+	if errors.Is(err, repo.ErrTOTPAlreadyConfirmed) {
+		return nil, status.Error(codes.FailedPrecondition, "mfa already enabled")
+	}
+	if err != nil {
+		slog.Error("failed to confirm totp", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	slog.Info("mfa enabled", slog.String("op", op), slog.String("user_id", claims.UserID))
+
+	return &authv1.ConfirmTOTPResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// VerifyMFA завершает вход со вторым фактором: обменивает токен второго шага из SignIn
+// и код из приложения или код восстановления на access и refresh токены.
+// Неверные коды учитываются ограничителем попыток входа наравне с неверными паролями.
+func (s *AuthServer) VerifyMFA(ctx context.Context, req *authv1.VerifyMFARequest) (*authv1.VerifyMFAResponse, error) {
+	op := "VerifyMFA"
+
+	if req.MfaToken == "" || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "mfa token and code required")
+	}
+
+	challengeHash := token.Hash(req.MfaToken)
+	userID, err := s.mfa.GetMFAChallenge(ctx, challengeHash)
+	if errors.Is(err, repo.ErrMFAChallengeNotFound) || errors.Is(err, repo.ErrMFAChallengeExpired) {
+		slog.Warn("invalid mfa token", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired mfa token")
+	}
+	if err != nil {
+		slog.Error("failed to get mfa challenge", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if errors.Is(err, repo.ErrUserNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired mfa token")
+	}
+	if err != nil {
+		slog.Error("failed to get user", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	clientIP := clientip.FromIncomingContext(ctx, s.cfg.ClientIPToken)
+	retryAfter, err := s.throttle.Check(ctx, user.Email, clientIP)
+	if err != nil {
+		slog.Error("failed to check login throttle", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	if retryAfter > 0 {
+		slog.Warn("login throttled", slog.String("op", op), slog.String("user_id", user.ID), slog.String("ip", clientIP), slog.Duration("retry_after", retryAfter))
+		return nil, throttledError(retryAfter)
+	}
+
+	ok, err := s.verifySecondFactor(ctx, op, user.ID, strings.TrimSpace(req.Code))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		slog.Warn("invalid mfa code", slog.String("op", op), slog.String("user_id", user.ID))
+		s.recordLoginFailure(ctx, op, user.Email, clientIP)
+
+		err := s.mfa.FailMFAChallenge(ctx, challengeHash, mfaMaxAttempts)
+		if errors.Is(err, repo.ErrMFAChallengeExhausted) || errors.Is(err, repo.ErrMFAChallengeNotFound) {
+			return nil, status.Error(codes.Unauthenticated, "too many invalid codes, sign in again")
+		}
+		if err != nil {
+			slog.Error("failed to record mfa failure", slog.String("op", op), slog.Any("error", err))
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid code")
+	}
+
+	// Гасится до выпуска токенов: из параллельных запросов с одним токеном успешен только один
+	err = s.mfa.ConsumeMFAChallenge(ctx, challengeHash)
+	if errors.Is(err, repo.ErrMFAChallengeNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired mfa token")
+	}
+	if err != nil {
+		slog.Error("failed to consume mfa challenge", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	if err := s.throttle.Succeed(ctx, user.Email); err != nil {
+		slog.Error("failed to reset login throttle", slog.String("op", op), slog.Any("error", err))
+	}
+
+	accessToken, refreshToken, err := s.issueTokens(ctx, op, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("user signed in", slog.String("op", op), slog.String("user_id", user.ID))
+
+	return &authv1.VerifyMFAResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// verifySecondFactor проверяет код из приложения или код восстановления.
+// Принятый TOTP код и использованный код восстановления повторно не принимаются.
+func (s *AuthServer) verifySecondFactor(ctx context.Context, op, userID, code string) (bool, error) {
+	if len(code) != totp.Digits {
+		err := s.mfa.UseRecoveryCode(ctx, userID, token.HashRecoveryCode(code))
+		if errors.Is(err, repo.ErrRecoveryCodeNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			slog.Error("failed to use recovery code", slog.String("op", op), slog.Any("error", err))
+			return false, status.Error(codes.Internal, "internal error")
+		}
+		slog.Warn("recovery code used", slog.String("op", op), slog.String("user_id", userID))
+		return true, nil
+	}
+
+	secret, err := s.mfa.GetTOTP(ctx, userID)
+	if errors.Is(err, repo.ErrTOTPNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		slog.Error("failed to get user totp", slog.String("op", op), slog.Any("error", err))
+		return false, status.Error(codes.Internal, "internal error")
+	}
+	if secret.ConfirmedAt == nil {
+		return false, nil
+	}
+
+	plain, err := s.openTOTPSecret(op, secret)
+	if err != nil {
+		return false, err
+	}
+
+	step, ok, err := totp.Validate(plain, code, time.Now())
+	if err != nil {
+		slog.Error("failed to validate totp code", slog.String("op", op), slog.Any("error", err))
+		return false, status.Error(codes.Internal, "internal error")
+	}
+	if !ok {
+		return false, nil
+	}
+
+	err = s.mfa.UseTOTPStep(ctx, userID, step)
+	if errors.Is(err, repo.ErrTOTPStepUsed) {
+		slog.Warn("totp code reused", slog.String("op", op), slog.String("user_id", userID))
+		return false, nil
+	}
+	if err != nil {
+		slog.Error("failed to store totp step", slog.String("op", op), slog.Any("error", err))
+		return false, status.Error(codes.Internal, "internal error")
+	}
+	return true, nil
+}
+
+// mfaEnabled сообщает, включён ли у пользователя второй фактор
+func (s *AuthServer) mfaEnabled(ctx context.Context, userID string) (bool, error) {
+	secret, err := s.mfa.GetTOTP(ctx, userID)
+	if errors.Is(err, repo.ErrTOTPNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return secret.ConfirmedAt != nil, nil
+}
+
+// openTOTPSecret расшифровывает сохранённый TOTP секрет пользователя
+func (s *AuthServer) openTOTPSecret(op string, secret *domain.TOTP) (string, error) {
+	plain, err := s.cfg.TOTPCipher.Open(secret.UserID, secret.Secret)
+	if err != nil {
+		slog.Error("failed to decrypt totp secret", slog.String("op", op), slog.String("user_id", secret.UserID), slog.Any("error", err))
+		return "", status.Error(codes.Internal, "internal error")
+	}
+	return plain, nil
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authv1 "golang-project/api/proto/gen/go/auth/v1"
+	"golang-project/services/auth-service/internal/repo"
+	"golang-project/services/auth-service/internal/token"
+	"golang-project/services/auth-service/internal/totp"
+)
+
+func TestAuthServer_ConfirmTOTP(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, Config{})
+	userID := env.createUser(t, testEmail, true)
+	access := signIn(t, env, userID)
+
+	enrolled, err := env.server.EnrollTOTP(ctx, &authv1.EnrollTOTPRequest{AccessToken: access, Password: testPassword})
+	if err != nil {
+		t.Fatalf("EnrollTOTP() error = %v", err)
+	}
+
+	_, err = env.server.ConfirmTOTP(ctx, &authv1.ConfirmTOTPRequest{AccessToken: access, Code: wrongCode(t, enrolled.Secret)})
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Fatalf("ConfirmTOTP() with wrong code code = %v, want InvalidArgument", code)
+	}
+	// Неверный код не включает второй фактор: вход по-прежнему по одному паролю
+	if got := signIn(t, env, userID); got == "" {
+		t.Fatal("SignIn() after failed confirmation returned no access token")
+	}
+
+	confirmed, err := env.server.ConfirmTOTP(ctx, &authv1.ConfirmTOTPRequest{
+		AccessToken: access,
+		Code:        totpCode(t, enrolled.Secret, 0),
+	})
+	if err != nil {
+		t.Fatalf("ConfirmTOTP() error = %v", err)
+	}
+	if len(confirmed.RecoveryCodes) != recoveryCodeCount {
+		t.Errorf("ConfirmTOTP() recovery codes = %d, want %d", len(confirmed.RecoveryCodes), recoveryCodeCount)
+	}
+
+	_, err = env.server.EnrollTOTP(ctx, &authv1.EnrollTOTPRequest{AccessToken: access, Password: testPassword})
+	if code := status.Code(err); code != codes.FailedPrecondition {
+		t.Errorf("EnrollTOTP() after confirmation code = %v, want FailedPrecondition", code)
+	}
+}
+
+func TestAuthServer_EnrollTOTP_EncryptsSecret(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, Config{})
+	userID := env.createUser(t, testEmail, true)
+	access := signIn(t, env, userID)
+
+	enrolled, err := env.server.EnrollTOTP(ctx, &authv1.EnrollTOTPRequest{AccessToken: access, Password: testPassword})
+	if err != nil {
+		t.Fatalf("EnrollTOTP() error = %v", err)
+	}
+	stored, err := env.store.GetTOTP(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetTOTP() error = %v", err)
+	}
+	if strings.Contains(stored.Secret, enrolled.Secret) {
+		t.Errorf("stored secret %q contains the plaintext secret", stored.Secret)
+	}
+
+	// Без ключа шифрования новые секреты не выдаются
+	env.server.cfg.TOTPCipher = nil
+	_, err = env.server.EnrollTOTP(ctx, &authv1.EnrollTOTPRequest{AccessToken: access, Password: testPassword})
+	if code := status.Code(err); code != codes.FailedPrecondition {
+		t.Errorf("EnrollTOTP() without key code = %v, want FailedPrecondition", code)
+	}
+}
+
+func TestAuthServer_VerifyMFA_LegacyPlaintextSecret(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, Config{})
+	userID := env.createUser(t, testEmail, true)
+
+	// Секрет, записанный до появления шифрования, продолжает работать
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+	if err := env.store.SetPendingTOTP(ctx, userID, secret); err != nil {
+		t.Fatalf("SetPendingTOTP() error = %v", err)
+	}
+	if err := env.store.ConfirmTOTP(ctx, userID, 1, nil); err != nil {
+		t.Fatalf("ConfirmTOTP() error = %v", err)
+	}
+
+	_, err = env.server.VerifyMFA(ctx, &authv1.VerifyMFARequest{MfaToken: mfaChallenge(t, env), Code: totpCode(t, secret, 0)})
+	if err != nil {
+		t.Errorf("VerifyMFA() error = %v", err)
+	}
+}
+
+func TestAuthServer_VerifyMFA(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// challengeTTL время жизни токена второго шага
+		challengeTTL time.Duration
+		// code возвращает код, предъявляемый в VerifyMFA
+		code     func(t *testing.T, secret string, recoveryCodes []string) string
+		wantCode codes.Code
+		wantMsg  string
+	}{
+		{
+			name:         "totp code",
+			challengeTTL: time.Minute,
+			// Код текущего шага уже потрачен на ConfirmTOTP, следующий шаг укладывается в допуск
+			code:     func(t *testing.T, secret string, _ []string) string { return totpCode(t, secret, 1) },
+			wantCode: codes.OK,
+		},
+		{
+			name:         "recovery code",
+			challengeTTL: time.Minute,
+			code:         func(_ *testing.T, _ string, recoveryCodes []string) string { return recoveryCodes[0] },
+			wantCode:     codes.OK,
+		},
+		{
+			name:         "wrong code",
+			challengeTTL: time.Minute,
+			code:         func(t *testing.T, secret string, _ []string) string { return wrongCode(t, secret) },
+			wantCode:     codes.Unauthenticated,
+			wantMsg:      "invalid code",
+		},
+		{
+			name:         "unknown recovery code",
+			challengeTTL: time.Minute,
+			code:         func(*testing.T, string, []string) string { return "aaaa-bbbb-cccc" },
+			wantCode:     codes.Unauthenticated,
+			wantMsg:      "invalid code",
+		},
+		{
+			name:         "expired challenge",
+			challengeTTL: -time.Minute,
+			code:         func(t *testing.T, secret string, _ []string) string { return totpCode(t, secret, 1) },
+			wantCode:     codes.Unauthenticated,
+			wantMsg:      "invalid or expired mfa token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t, Config{MFAChallengeTTL: tt.challengeTTL})
+			userID := env.createUser(t, testEmail, true)
+			secret, recoveryCodes := enableMFA(t, env, userID)
+			mfaToken := mfaChallenge(t, env)
+
+			resp, err := env.server.VerifyMFA(ctx, &authv1.VerifyMFARequest{MfaToken: mfaToken, Code: tt.code(t, secret, recoveryCodes)})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("VerifyMFA() code = %v, want %v (err = %v)", code, tt.wantCode, err)
+			}
+			if tt.wantCode != codes.OK {
+				if msg := status.Convert(err).Message(); msg != tt.wantMsg {
+					t.Errorf("VerifyMFA() message = %q, want %q", msg, tt.wantMsg)
+				}
+				return
+			}
+
+			validated, err := env.server.ValidateToken(ctx, &authv1.ValidateTokenRequest{Token: resp.AccessToken})
+			if err != nil {
+				t.Fatalf("ValidateToken() error = %v", err)
+			}
+			if !validated.Valid || validated.UserId != userID {
+				t.Errorf("ValidateToken() = %v for %q, want valid for %s", validated.Valid, validated.UserId, userID)
+			}
+			if resp.RefreshToken == "" {
+				t.Error("VerifyMFA() returned no refresh token")
+			}
+
+			// Токен второго шага одноразовый
+			_, err = env.server.VerifyMFA(ctx, &authv1.VerifyMFARequest{MfaToken: mfaToken, Code: recoveryCodes[1]})
+			if code := status.Code(err); code != codes.Unauthenticated {
+				t.Errorf("VerifyMFA() with used mfa token code = %v, want Unauthenticated", code)
+			}
+		})
+	}
+}
+
+func TestAuthServer_VerifyMFA_AttemptLimit(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, Config{})
+	userID := env.createUser(t, testEmail, true)
+	secret, _ := enableMFA(t, env, userID)
+	mfaToken := mfaChallenge(t, env)
+
+	for i := 1; i <= mfaMaxAttempts; i++ {
+		_, err := env.server.VerifyMFA(ctx, &authv1.VerifyMFARequest{MfaToken: mfaToken, Code: wrongCode(t, secret)})
+		if code := status.Code(err); code != codes.Unauthenticated {
+			t.Fatalf("VerifyMFA() attempt %d code = %v, want Unauthenticated", i, code)
+		}
+		wantMsg := "invalid code"
+		if i == mfaMaxAttempts {
+			wantMsg = "too many invalid codes, sign in again"
+		}
+		if msg := status.Convert(err).Message(); msg != wantMsg {
+			t.Errorf("VerifyMFA() attempt %d message = %q, want %q", i, msg, wantMsg)
+		}
+	}
+
+	// После исчерпания попыток токен второго шага погашен: верный код с ним уже не поможет
+	if _, err := env.store.GetMFAChallenge(ctx, token.Hash(mfaToken)); !errors.Is(err, repo.ErrMFAChallengeNotFound) {
+		t.Errorf("GetMFAChallenge() after attempt limit error = %v, want %v", err, repo.ErrMFAChallengeNotFound)
+	}
+	_, err := env.server.VerifyMFA(ctx, &authv1.VerifyMFARequest{
+		MfaToken: mfaToken,
+		Code:     totpCode(t, secret, 1),
+	})
+	if code := status.Code(err); code == codes.OK {
+		t.Error("VerifyMFA() with exhausted mfa token succeeded")
+	}
+}
+
+func TestAuthServer_VerifyMFA_RecoveryCodeSingleUse(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, Config{})
+	userID := env.createUser(t, testEmail, true)
+	_, recoveryCodes := enableMFA(t, env, userID)
+
+	if _, err := env.server.VerifyMFA(ctx, &authv1.VerifyMFARequest{MfaToken: mfaChallenge(t, env), Code: recoveryCodes[0]}); err != nil {
+		t.Fatalf("VerifyMFA() with recovery code error = %v", err)
+	}
+
+	mfaToken := mfaChallenge(t, env)
+	_, err := env.server.VerifyMFA(ctx, &authv1.VerifyMFARequest{MfaToken: mfaToken, Code: recoveryCodes[0]})
+	if code := status.Code(err); code != codes.Unauthenticated {
+		t.Fatalf("VerifyMFA() with used recovery code code = %v, want Unauthenticated", code)
+	}
+
+	// Остальные коды восстановления продолжают работать
+	if _, err := env.server.VerifyMFA(ctx, &authv1.VerifyMFARequest{MfaToken: mfaToken, Code: recoveryCodes[1]}); err != nil {
+		t.Errorf("VerifyMFA() with unused recovery code error = %v", err)
+	}
+}
+
+// enableMFA подключает пользователю TOTP и возвращает секрет и коды восстановления
+func enableMFA(t *testing.T, env *testEnv, userID string) (string, []string) {
+	t.Helper()
+
+	ctx := context.Background()
+	access := signIn(t, env, userID)
+	enrolled, err := env.server.EnrollTOTP(ctx, &authv1.EnrollTOTPRequest{AccessToken: access, Password: testPassword})
+	if err != nil {
+		t.Fatalf("EnrollTOTP() error = %v", err)
+	}
+	confirmed, err := env.server.ConfirmTOTP(ctx, &authv1.ConfirmTOTPRequest{
+		AccessToken: access,
+		Code:        totpCode(t, enrolled.Secret, 0),
+	})
+	if err != nil {
+		t.Fatalf("ConfirmTOTP() error = %v", err)
+	}
+	return enrolled.Secret, confirmed.RecoveryCodes
+}
+
+// mfaChallenge входит по паролю и возвращает токен второго шага
+func mfaChallenge(t *testing.T, env *testEnv) string {
+	t.Helper()
+
+	resp, err := env.server.SignIn(context.Background(), &authv1.SignInRequest{Email: testEmail, Password: testPassword})
+	if err != nil {
+		t.Fatalf("SignIn() error = %v", err)
+	}
+	if !resp.MfaRequired || resp.MfaToken == "" {
+		t.Fatalf("SignIn() mfa required = %v, token %q, want a second step", resp.MfaRequired, resp.MfaToken)
+	}
+	return resp.MfaToken
+}
+
+// totpCode возвращает код шага, отстоящего от текущего на offset
+func totpCode(t *testing.T, secret string, offset int64) string {
+	t.Helper()
+
+	code, err := totp.Code(secret, totp.Step(time.Now())+offset)
+	if err != nil {
+		t.Fatalf("Code() error = %v", err)
+	}
+	return code
+}
+
+// wrongCode возвращает код, не совпадающий ни с одним кодом из окна допуска
+func wrongCode(t *testing.T, secret string) string {
+	t.Helper()
+
+	valid := make(map[string]bool)
+	for offset := int64(-2); offset <= 2; offset++ {
+		valid[totpCode(t, secret, offset)] = true
+	}
+	for i := 0; ; i++ {
+		if code := fmt.Sprintf("%0*d", totp.Digits, i); !valid[code] {
+			return code
+		}
+	}
+}
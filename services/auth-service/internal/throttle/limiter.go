@@ -0,0 +1,175 @@
+package throttle
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"golang-project/services/auth-service/internal/domain"
+)
+
+// Policy правила блокировки для одного вида ключей
+type Policy struct {
+	// MaxFailures количество неудач подряд, после которого ключ блокируется
+	MaxFailures int
+	// BaseLockout длительность первой блокировки; каждая следующая неудача удваивает её
+	BaseLockout time.Duration
+	// MaxLockout верхняя граница длительности блокировки
+	MaxLockout time.Duration
+}
+
+// lockout возвращает длительность блокировки после failures неудач; 0 - без блокировки
+func (p Policy) lockout(failures int) time.Duration {
+	if failures < p.MaxFailures {
+		return 0
+	}
+	d := p.BaseLockout
+	for i := p.MaxFailures; i < failures && d < p.MaxLockout; i++ {
+		d *= 2
+	}
+	if d > p.MaxLockout {
+		d = p.MaxLockout
+	}
+	return d
+}
+
+// Config настройки ограничителя попыток входа
+type Config struct {
+	// Email правила для попыток входа в один аккаунт
+	Email Policy
+	// IP правила для попыток входа с одного адреса; лимит выше, чем для email,
+	// так как за одним адресом может находиться много пользователей
+	IP Policy
+	// Window время без неудач, после которого счётчик ключа обнуляется
+	Window time.Duration
+	// PurgeInterval период удаления устаревших счётчиков
+	PurgeInterval time.Duration
+}
+
+// Limiter ограничивает перебор паролей: считает неудачные попытки входа по email
+// и по IP клиента и блокирует ключ с экспоненциально растущей длительностью
+type Limiter struct {
+	store domain.LoginAttemptStore
+	cfg   Config
+	now   func() time.Time
+}
+
+// NewLimiter создаёт ограничитель. По умолчанию email блокируется после 5 неудач,
+// IP - после 20; блокировка от 30 секунд до 15 минут, счётчик живёт час.
+func NewLimiter(store domain.LoginAttemptStore, cfg Config) *Limiter {
+	cfg.Email = withDefaults(cfg.Email, 5)
+	cfg.IP = withDefaults(cfg.IP, 20)
+	if cfg.Window == 0 {
+		cfg.Window = time.Hour
+	}
+	if cfg.PurgeInterval == 0 {
+		cfg.PurgeInterval = 10 * time.Minute
+	}
+	return &Limiter{
+		store: store,
+		cfg:   cfg,
+		now:   time.Now,
+	}
+}
+
+func withDefaults(p Policy, maxFailures int) Policy {
+	if p.MaxFailures == 0 {
+		p.MaxFailures = maxFailures
+	}
+	if p.BaseLockout == 0 {
+		p.BaseLockout = 30 * time.Second
+	}
+	if p.MaxLockout == 0 {
+		p.MaxLockout = 15 * time.Minute
+	}
+	return p
+}
+
+// Check возвращает, сколько осталось ждать до следующей попытки входа; 0 - вход разрешён
+func (l *Limiter) Check(ctx context.Context, email, ip string) (time.Duration, error) {
+	now := l.now()
+	var retryAfter time.Duration
+	for _, key := range keys(email, ip) {
+		lockedUntil, err := l.store.LockedUntil(ctx, key.name)
+		if err != nil {
+			return 0, err
+		}
+		if wait := lockedUntil.Sub(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	return retryAfter, nil
+}
+
+// Fail учитывает неудачную попытку входа и блокирует ключи, превысившие лимит
+func (l *Limiter) Fail(ctx context.Context, email, ip string) error {
+	for _, key := range keys(email, ip) {
+		failures, err := l.store.RecordFailure(ctx, key.name, l.cfg.Window)
+		if err != nil {
+			return err
+		}
+
+		policy := l.cfg.Email
+		if key.ip {
+			policy = l.cfg.IP
+		}
+		if lockout := policy.lockout(failures); lockout > 0 {
+			if err := l.store.Lock(ctx, key.name, l.now().Add(lockout)); err != nil {
+				return err
+			}
+			slog.Warn("login locked out", slog.String("key", key.name), slog.Int("failures", failures), slog.Duration("lockout", lockout))
+		}
+	}
+	return nil
+}
+
+// Succeed сбрасывает счётчик аккаунта после успешного входа.
+// Счётчик IP не сбрасывается: иначе подбор паролей к чужим аккаунтам можно
+// было бы маскировать входами в свой.
+func (l *Limiter) Succeed(ctx context.Context, email string) error {
+	return l.store.Reset(ctx, emailKey(email))
+}
+
+// Run периодически удаляет устаревшие счётчики до отмены контекста
+func (l *Limiter) Run(ctx context.Context) {
+	ticker := time.NewTicker(l.cfg.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		purged, err := l.store.PurgeExpired(ctx, l.cfg.Window)
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.Error("failed to purge login attempts", slog.Any("error", err))
+			}
+			continue
+		}
+		if purged > 0 {
+			slog.Info("purged login attempts", slog.Int64("count", purged))
+		}
+	}
+}
+
+type key struct {
+	name string
+	ip   bool
+}
+
+// keys возвращает ключи счётчиков попытки; IP пропускается, если неизвестен
+func keys(email, ip string) []key {
+	result := []key{{name: emailKey(email)}}
+	if ip != "" {
+		result = append(result, key{name: "ip:" + ip, ip: true})
+	}
+	return result
+}
+
+func emailKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,76 @@
+package throttle
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	store := NewMemoryStore()
+	store.now = clock
+	limiter := NewLimiter(store, Config{
+		Email:  Policy{MaxFailures: 3, BaseLockout: time.Minute, MaxLockout: 4 * time.Minute},
+		IP:     Policy{MaxFailures: 5, BaseLockout: time.Minute, MaxLockout: 4 * time.Minute},
+		Window: time.Hour,
+	})
+	limiter.now = clock
+
+	ctx := context.Background()
+	check := func(email, ip string, want time.Duration) {
+		t.Helper()
+		got, err := limiter.Check(ctx, email, ip)
+		if err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("Check(%q, %q) = %v, want %v", email, ip, got, want)
+		}
+	}
+	fail := func(email, ip string) {
+		t.Helper()
+		if err := limiter.Fail(ctx, email, ip); err != nil {
+			t.Fatalf("Fail() error = %v", err)
+		}
+	}
+
+	// Блокировка аккаунта после MaxFailures неудач, email сравнивается без учёта регистра
+	fail("user@example.com", "10.0.0.1")
+	fail("User@Example.com", "10.0.0.1")
+	check("user@example.com", "10.0.0.2", 0)
+	fail("user@example.com", "10.0.0.1")
+	check("user@example.com", "10.0.0.2", time.Minute)
+
+	// Каждая следующая неудача удваивает блокировку вплоть до MaxLockout
+	for _, want := range []time.Duration{2 * time.Minute, 4 * time.Minute, 4 * time.Minute} {
+		now = now.Add(5 * time.Minute)
+		check("user@example.com", "10.0.0.2", 0)
+		fail("user@example.com", "10.0.0.2")
+		check("user@example.com", "10.0.0.2", want)
+	}
+
+	// Успешный вход сбрасывает счётчик аккаунта
+	now = now.Add(5 * time.Minute)
+	if err := limiter.Succeed(ctx, "user@example.com"); err != nil {
+		t.Fatalf("Succeed() error = %v", err)
+	}
+	fail("user@example.com", "")
+	check("user@example.com", "", 0)
+
+	// Перебор разных аккаунтов с одного адреса блокирует адрес
+	for i := 0; i < 5; i++ {
+		fail(fmt.Sprintf("victim%d@example.com", i), "10.0.0.3")
+	}
+	check("another@example.com", "10.0.0.3", time.Minute)
+	check("another@example.com", "10.0.0.4", 0)
+
+	// После окна без неудач счётчик начинается заново
+	now = now.Add(2 * time.Hour)
+	fail("user@example.com", "")
+	fail("user@example.com", "")
+	check("user@example.com", "", 0)
+}
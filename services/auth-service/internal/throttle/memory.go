@@ -0,0 +1,97 @@
+package throttle
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore хранит попытки входа в памяти процесса.
+// Подходит для тестов и одного экземпляра сервиса: счётчики не переживают
+// перезапуск и не видны другим репликам.
+type MemoryStore struct {
+	mu       sync.Mutex
+	attempts map[string]*attempt
+	now      func() time.Time
+}
+
+type attempt struct {
+	failures      int
+	lastFailureAt time.Time
+	lockedUntil   time.Time
+}
+
+// NewMemoryStore создаёт пустое in-memory хранилище
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		attempts: make(map[string]*attempt),
+		now:      time.Now,
+	}
+}
+
+func (s *MemoryStore) RecordFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	a, ok := s.attempts[key]
+	if !ok {
+		a = &attempt{}
+		s.attempts[key] = a
+	}
+	if !a.lastFailureAt.After(now.Add(-window)) {
+		a.failures = 0
+	}
+	a.failures++
+	a.lastFailureAt = now
+	return a.failures, nil
+}
+
+func (s *MemoryStore) Lock(ctx context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.attempts[key]
+	if !ok {
+		a = &attempt{lastFailureAt: s.now()}
+		s.attempts[key] = a
+	}
+	if until.After(a.lockedUntil) {
+		a.lockedUntil = until
+	}
+	return nil
+}
+
+func (s *MemoryStore) LockedUntil(ctx context.Context, key string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.attempts[key]
+	if !ok || !a.lockedUntil.After(s.now()) {
+		return time.Time{}, nil
+	}
+	return a.lockedUntil, nil
+}
+
+func (s *MemoryStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
+
+func (s *MemoryStore) PurgeExpired(ctx context.Context, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var purged int64
+	for key, a := range s.attempts {
+		if !a.lastFailureAt.After(now.Add(-window)) && !a.lockedUntil.After(now) {
+			delete(s.attempts, key)
+			purged++
+		}
+	}
+	return purged, nil
+}
@@ -0,0 +1,78 @@
+package throttle
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// PostgresStore хранит попытки входа в таблице login_attempts,
+// общей для всех реплик auth-service
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore создаёт хранилище поверх Postgres
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) RecordFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	query := `
+		INSERT INTO login_attempts (key, failures, last_failure_at)
+		VALUES ($1, 1, NOW())
+		ON CONFLICT (key) DO UPDATE
+		SET failures = CASE
+				WHEN login_attempts.last_failure_at <= NOW() - make_interval(secs => $2) THEN 1
+				ELSE login_attempts.failures + 1
+			END,
+			last_failure_at = NOW()
+		RETURNING failures
+	`
+
+	var failures int
+	err := s.db.QueryRowContext(ctx, query, key, window.Seconds()).Scan(&failures)
+	return failures, err
+}
+
+func (s *PostgresStore) Lock(ctx context.Context, key string, until time.Time) error {
+	query := `
+		INSERT INTO login_attempts (key, failures, last_failure_at, locked_until)
+		VALUES ($1, 0, NOW(), $2)
+		ON CONFLICT (key) DO UPDATE
+		SET locked_until = GREATEST(login_attempts.locked_until, EXCLUDED.locked_until)
+	`
+
+	_, err := s.db.ExecContext(ctx, query, key, until)
+	return err
+}
+
+func (s *PostgresStore) LockedUntil(ctx context.Context, key string) (time.Time, error) {
+	var lockedUntil time.Time
+	query := `SELECT locked_until FROM login_attempts WHERE key = $1 AND locked_until > NOW()`
+
+	err := s.db.QueryRowContext(ctx, query, key).Scan(&lockedUntil)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	return lockedUntil, err
+}
+
+func (s *PostgresStore) Reset(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM login_attempts WHERE key = $1`, key)
+	return err
+}
+
+func (s *PostgresStore) PurgeExpired(ctx context.Context, window time.Duration) (int64, error) {
+	query := `
+		DELETE FROM login_attempts
+		WHERE last_failure_at <= NOW() - make_interval(secs => $1)
+			AND (locked_until IS NULL OR locked_until <= NOW())
+	`
+
+	res, err := s.db.ExecContext(ctx, query, window.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
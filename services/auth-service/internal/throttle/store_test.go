@@ -0,0 +1,145 @@
+package throttle
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"golang-project/services/auth-service/internal/domain"
+)
+
+// openTestDB подключается к Postgres из DB_DSN и создаёт таблицу login_attempts
+// в отдельной схеме. Тест пропускается, если DB_DSN не задан.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		t.Skip("DB_DSN is not set, skipping Postgres test")
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("throttle_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	db, err := sql.Open("postgres", dsn+sep+"search_path="+schema)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migration, err := os.ReadFile("../../migrations/0010_init_login_attempts.up.sql")
+	if err != nil {
+		t.Fatalf("failed to read migration: %v", err)
+	}
+	if _, err := db.Exec(string(migration)); err != nil {
+		t.Fatalf("failed to apply migration: %v", err)
+	}
+
+	return db
+}
+
+func TestStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) domain.LoginAttemptStore{
+		"memory": func(t *testing.T) domain.LoginAttemptStore {
+			return NewMemoryStore()
+		},
+		"postgres": func(t *testing.T) domain.LoginAttemptStore {
+			return NewPostgresStore(openTestDB(t))
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			ctx := context.Background()
+
+			for want := 1; want <= 3; want++ {
+				got, err := store.RecordFailure(ctx, "email:a@example.com", time.Hour)
+				if err != nil {
+					t.Fatalf("RecordFailure() error = %v", err)
+				}
+				if got != want {
+					t.Errorf("RecordFailure() = %d, want %d", got, want)
+				}
+			}
+
+			// Неудача после окна начинает счёт заново
+			time.Sleep(20 * time.Millisecond)
+			got, err := store.RecordFailure(ctx, "email:a@example.com", 10*time.Millisecond)
+			if err != nil {
+				t.Fatalf("RecordFailure() error = %v", err)
+			}
+			if got != 1 {
+				t.Errorf("RecordFailure() after window = %d, want 1", got)
+			}
+
+			lockedUntil, err := store.LockedUntil(ctx, "email:a@example.com")
+			if err != nil {
+				t.Fatalf("LockedUntil() error = %v", err)
+			}
+			if !lockedUntil.IsZero() {
+				t.Errorf("LockedUntil() = %v before Lock", lockedUntil)
+			}
+
+			until := time.Now().Add(time.Hour).Truncate(time.Second)
+			if err := store.Lock(ctx, "email:a@example.com", until); err != nil {
+				t.Fatalf("Lock() error = %v", err)
+			}
+			// Более короткая блокировка не сокращает текущую
+			if err := store.Lock(ctx, "email:a@example.com", until.Add(-time.Minute)); err != nil {
+				t.Fatalf("Lock() error = %v", err)
+			}
+			lockedUntil, err = store.LockedUntil(ctx, "email:a@example.com")
+			if err != nil {
+				t.Fatalf("LockedUntil() error = %v", err)
+			}
+			if !lockedUntil.Equal(until) {
+				t.Errorf("LockedUntil() = %v, want %v", lockedUntil, until)
+			}
+
+			if _, err := store.RecordFailure(ctx, "ip:10.0.0.1", time.Hour); err != nil {
+				t.Fatalf("RecordFailure() error = %v", err)
+			}
+			time.Sleep(20 * time.Millisecond)
+
+			// Заблокированный ключ не удаляется, даже если счётчик устарел
+			purged, err := store.PurgeExpired(ctx, 10*time.Millisecond)
+			if err != nil {
+				t.Fatalf("PurgeExpired() error = %v", err)
+			}
+			if purged != 1 {
+				t.Errorf("PurgeExpired() = %d, want 1", purged)
+			}
+
+			if err := store.Reset(ctx, "email:a@example.com"); err != nil {
+				t.Fatalf("Reset() error = %v", err)
+			}
+			lockedUntil, err = store.LockedUntil(ctx, "email:a@example.com")
+			if err != nil {
+				t.Fatalf("LockedUntil() error = %v", err)
+			}
+			if !lockedUntil.IsZero() {
+				t.Errorf("LockedUntil() = %v after Reset", lockedUntil)
+			}
+		})
+	}
+}
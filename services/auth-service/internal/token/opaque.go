@@ -0,0 +1,29 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// opaqueTokenLength длина случайной части токена в байтах
+const opaqueTokenLength = 32
+
+// NewOpaque генерирует случайный непрозрачный токен и его хеш для хранения в БД
+func NewOpaque() (token, hash string, err error) {
+	b := make([]byte, opaqueTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, Hash(token), nil
+}
+
+// Hash возвращает SHA-256 хеш токена.
+// Токен содержит 256 бит энтропии, поэтому медленный KDF здесь не нужен.
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
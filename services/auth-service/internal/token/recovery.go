@@ -0,0 +1,36 @@
+package token
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+)
+
+// recoveryCodeLength длина кода восстановления в байтах (80 бит)
+const recoveryCodeLength = 10
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewRecoveryCode генерирует код восстановления вида XXXX-XXXX-XXXX-XXXX и его хеш.
+// 80 бит энтропии достаточно, чтобы хранить код как обычный токен, без медленного KDF.
+func NewRecoveryCode() (code, hash string, err error) {
+	b := make([]byte, recoveryCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	raw := recoveryEncoding.EncodeToString(b)
+	parts := make([]string, 0, len(raw)/4)
+	for i := 0; i < len(raw); i += 4 {
+		parts = append(parts, raw[i:i+4])
+	}
+	return strings.Join(parts, "-"), Hash(raw), nil
+}
+
+// HashRecoveryCode возвращает хеш введённого пользователем кода восстановления.
+// Регистр, пробелы и дефисы не учитываются.
+func HashRecoveryCode(code string) string {
+	code = strings.ToUpper(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	return Hash(code)
+}
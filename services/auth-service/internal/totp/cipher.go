@@ -0,0 +1,89 @@
+package totp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// KeySize длина ключа шифрования секретов в байтах (AES-256)
+	KeySize = 32
+	// sealedPrefix отличает зашифрованный секрет от открытого, записанного до шифрования
+	sealedPrefix = "v1:"
+)
+
+// ErrSealedSecret зашифрованный секрет нельзя прочитать без ключа
+var ErrSealedSecret = errors.New("totp secret is encrypted, key required")
+
+// SecretCipher шифрует TOTP секреты перед записью в БД (AES-256-GCM).
+// Секрет привязан к пользователю через associated data: скопированный в чужую
+// строку user_totp шифротекст не расшифруется.
+type SecretCipher struct {
+	aead cipher.AEAD
+}
+
+// NewSecretCipher создаёт шифр из ключа длиной KeySize байт
+func NewSecretCipher(key []byte) (*SecretCipher, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("totp secret key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &SecretCipher{aead: aead}, nil
+}
+
+// ParseSecretKey декодирует ключ из base64 (например, вывод openssl rand -base64 32);
+// длину проверяет NewSecretCipher
+func ParseSecretKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("decode totp secret key: %w", err)
+	}
+	return key, nil
+}
+
+// Seal шифрует секрет пользователя userID для хранения в БД
+func (c *SecretCipher) Seal(userID, secret string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(secret), []byte(userID))
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open расшифровывает сохранённый секрет. Значения без префикса записаны до появления
+// шифрования и возвращаются как есть. c может быть nil: тогда читаются только такие значения.
+func (c *SecretCipher) Open(userID, stored string) (string, error) {
+	encoded, ok := strings.CutPrefix(stored, sealedPrefix)
+	if !ok {
+		return stored, nil
+	}
+	if c == nil {
+		return "", ErrSealedSecret
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("decode totp secret: %w", err)
+	}
+	if len(sealed) < c.aead.NonceSize() {
+		return "", errors.New("totp secret is too short")
+	}
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	secret, err := c.aead.Open(nil, nonce, ciphertext, []byte(userID))
+	if err != nil {
+		return "", fmt.Errorf("decrypt totp secret: %w", err)
+	}
+	return string(secret), nil
+}
@@ -0,0 +1,113 @@
+package totp
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newTestCipher(t *testing.T) *SecretCipher {
+	t.Helper()
+
+	c, err := NewSecretCipher(bytes.Repeat([]byte{7}, KeySize))
+	if err != nil {
+		t.Fatalf("NewSecretCipher() error = %v", err)
+	}
+	return c
+}
+
+func TestSecretCipherRoundTrip(t *testing.T) {
+	c := newTestCipher(t)
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+
+	sealed, err := c.Seal("user-1", secret)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if strings.Contains(sealed, secret) {
+		t.Fatalf("Seal() = %q contains the plaintext secret", sealed)
+	}
+	if again, _ := c.Seal("user-1", secret); again == sealed {
+		t.Error("Seal() is deterministic, want a random nonce")
+	}
+
+	got, err := c.Open("user-1", sealed)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if got != secret {
+		t.Errorf("Open() = %q, want %q", got, secret)
+	}
+}
+
+func TestSecretCipherOpenRejects(t *testing.T) {
+	c := newTestCipher(t)
+	sealed, err := c.Seal("user-1", "JBSWY3DPEHPK3PXP")
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	other, err := NewSecretCipher(bytes.Repeat([]byte{8}, KeySize))
+	if err != nil {
+		t.Fatalf("NewSecretCipher() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		cipher *SecretCipher
+		userID string
+		stored string
+	}{
+		{name: "other user", cipher: c, userID: "user-2", stored: sealed},
+		{name: "other key", cipher: other, userID: "user-1", stored: sealed},
+		{name: "tampered", cipher: c, userID: "user-1", stored: sealed[:len(sealed)-2] + "AA"},
+		{name: "truncated", cipher: c, userID: "user-1", stored: sealedPrefix + "AAAA"},
+		{name: "not base64", cipher: c, userID: "user-1", stored: sealedPrefix + "!!!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.cipher.Open(tt.userID, tt.stored); err == nil {
+				t.Errorf("Open() = %q, want error", got)
+			}
+		})
+	}
+}
+
+func TestSecretCipherOpenLegacy(t *testing.T) {
+	const legacy = "JBSWY3DPEHPK3PXP"
+
+	// Секреты, записанные до шифрования, читаются и с ключом, и без него
+	for _, c := range []*SecretCipher{newTestCipher(t), nil} {
+		if got, err := c.Open("user-1", legacy); err != nil || got != legacy {
+			t.Errorf("Open(legacy) = %q, %v, want %q", got, err, legacy)
+		}
+	}
+
+	sealed, err := newTestCipher(t).Seal("user-1", legacy)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	var none *SecretCipher
+	if _, err := none.Open("user-1", sealed); !errors.Is(err, ErrSealedSecret) {
+		t.Errorf("Open() without key error = %v, want %v", err, ErrSealedSecret)
+	}
+}
+
+func TestNewSecretCipherKeySize(t *testing.T) {
+	if _, err := NewSecretCipher(make([]byte, 16)); err == nil {
+		t.Error("NewSecretCipher() error = nil for 16-byte key")
+	}
+	key, err := ParseSecretKey("BwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwc=")
+	if err != nil {
+		t.Fatalf("ParseSecretKey() error = %v", err)
+	}
+	if _, err := NewSecretCipher(key); err != nil {
+		t.Errorf("NewSecretCipher() error = %v", err)
+	}
+	if _, err := ParseSecretKey("not base64"); err == nil {
+		t.Error("ParseSecretKey() error = nil for invalid base64")
+	}
+}
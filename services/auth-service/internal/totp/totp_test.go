@@ -0,0 +1,73 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestHOTPRFC6238Vectors(t *testing.T) {
+	// Тестовые значения RFC 6238, приложение B (SHA1, 8 цифр)
+	key := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+	for _, tt := range tests {
+		step := Step(time.Unix(tt.unix, 0))
+		if got := hotp(key, uint64(step), 8); got != tt.want {
+			t.Errorf("hotp(t=%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(1111111111, 0)
+	current := Step(now)
+
+	for _, step := range []int64{current - 1, current, current + 1} {
+		code, err := Code(secret, step)
+		if err != nil {
+			t.Fatalf("Code() error = %v", err)
+		}
+		got, ok, err := Validate(secret, code, now)
+		if err != nil || !ok || got != step {
+			t.Errorf("Validate(step %d) = %d, %v, %v", step, got, ok, err)
+		}
+	}
+
+	// Код вне допуска по времени не принимается
+	old, _ := Code(secret, current-2)
+	if _, ok, _ := Validate(secret, old, now); ok {
+		t.Error("Validate() accepted code outside skew")
+	}
+	if _, ok, _ := Validate(secret, "12345", now); ok {
+		t.Error("Validate() accepted short code")
+	}
+	if _, _, err := Validate("not base32!", "123456", now); err == nil {
+		t.Error("Validate() error = nil for invalid secret")
+	}
+}
+
+func TestURI(t *testing.T) {
+	u, err := url.Parse(URI("Notes", "user@example.com", "JBSWY3DPEHPK3PXP"))
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/Notes:user@example.com" {
+		t.Errorf("URI() = %s", u)
+	}
+	q := u.Query()
+	if q.Get("secret") != "JBSWY3DPEHPK3PXP" || q.Get("issuer") != "Notes" || q.Get("digits") != "6" || q.Get("period") != "30" {
+		t.Errorf("URI() query = %v", q)
+	}
+}
@@ -0,0 +1,166 @@
+package validator
+
+import (
+	"bufio"
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsList string
+
+// commonPasswordRank ранг пароля в списке распространённых, начиная с 1.
+// Список упорядочен по частоте и служит словарём для EstimateEntropy.
+var commonPasswordRank = func() map[string]int {
+	ranks := make(map[string]int)
+	for i, p := range strings.Fields(commonPasswordsList) {
+		if _, ok := ranks[p]; !ok {
+			ranks[p] = i + 1
+		}
+	}
+	return ranks
+}()
+
+// IsCommonPassword проверяет пароль по встроенному списку распространённых без учёта регистра
+func IsCommonPassword(password string) bool {
+	_, ok := commonPasswordRank[strings.ToLower(password)]
+	return ok
+}
+
+// hashPrefixLength длина префикса SHA-1 для запроса диапазона (k-анонимность)
+const hashPrefixLength = 5
+
+// PwnedRangeFile проверяет пароли по локальной копии базы Have I Been Pwned:
+// текстовому файлу со строками "SHA1:COUNT", отсортированному по хешу.
+// Поиск повторяет схему k-анонимности API: по первым 5 символам SHA-1 читается
+// диапазон суффиксов, и совпадение ищется среди них. Так файл можно заменить
+// удалённым range-API, не передавая наружу ни пароль, ни его полный хеш.
+type PwnedRangeFile struct {
+	path string
+}
+
+// NewPwnedRangeFile открывает базу утечек из файла path
+func NewPwnedRangeFile(path string) (*PwnedRangeFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	f.Close()
+	return &PwnedRangeFile{path: path}, nil
+}
+
+// IsBreached проверяет, встречался ли пароль в утечках
+func (p *PwnedRangeFile) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, err := p.Range(hash[:hashPrefixLength])
+	if err != nil {
+		return false, err
+	}
+	for _, suffix := range suffixes {
+		if suffix == hash[hashPrefixLength:] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Range возвращает суффиксы хешей с префиксом prefix (5 шестнадцатеричных символов)
+func (p *PwnedRangeFile) Range(prefix string) ([]string, error) {
+	prefix = strings.ToUpper(prefix)
+	if len(prefix) != hashPrefixLength {
+		return nil, fmt.Errorf("hash prefix must be %d chars", hashPrefixLength)
+	}
+
+	f, err := os.Open(p.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+
+	// Бинарный поиск первой строки с хешем не меньше префикса.
+	// lo всегда указывает на начало строки, и все строки до него меньше префикса.
+	lo, hi := int64(0), size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		start, line, err := lineAt(f, mid, size)
+		if err == io.EOF {
+			hi = mid
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if strings.ToUpper(rangeKey(line)) < prefix {
+			lo = start + int64(len(line)) + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	// Последняя строка файла может быть без перевода строки
+	if lo > size {
+		lo = size
+	}
+
+	var suffixes []string
+	scanner := bufio.NewScanner(io.NewSectionReader(f, lo, size-lo))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		hash, _, _ := strings.Cut(line, ":")
+		hash = strings.ToUpper(hash)
+		if !strings.HasPrefix(hash, prefix) {
+			break
+		}
+		suffixes = append(suffixes, hash[hashPrefixLength:])
+	}
+	return suffixes, scanner.Err()
+}
+
+// lineAt читает первую строку, начинающуюся не раньше off.
+// Возвращает смещение её начала и содержимое без перевода строки.
+func lineAt(f *os.File, off, size int64) (int64, string, error) {
+	start := off
+	if off > 0 {
+		// Строка начинается с off, только если предыдущий байт - перевод строки
+		start = off - 1
+	}
+
+	r := bufio.NewReader(io.NewSectionReader(f, start, size-start))
+	if off > 0 {
+		skipped, err := r.ReadString('\n')
+		if err != nil {
+			return 0, "", io.EOF
+		}
+		start += int64(len(skipped))
+	}
+
+	line, err := r.ReadString('\n')
+	if err == io.EOF && line == "" {
+		return 0, "", io.EOF
+	}
+	if err != nil && err != io.EOF {
+		return 0, "", err
+	}
+	return start, strings.TrimSuffix(line, "\n"), nil
+}
+
+// rangeKey префикс хеша строки "SHA1:COUNT"
+func rangeKey(line string) string {
+	if len(line) < hashPrefixLength {
+		return line
+	}
+	return line[:hashPrefixLength]
+}
@@ -0,0 +1,217 @@
+package validator
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// keyboardRows ряды клавиатуры, по которым набирают пароли вроде qwerty и asdf
+var keyboardRows = []string{"qwertyuiop", "asdfghjkl", "zxcvbnm", "1234567890", "qazwsxedcrfv"}
+
+// leetReplacer заменяет типичные подстановки (p@ssw0rd) обратно на буквы
+var leetReplacer = strings.NewReplacer("4", "a", "@", "a", "3", "e", "1", "i", "0", "o", "$", "s", "5", "s", "7", "t")
+
+// minPatternLength минимальная длина шаблона; более короткие совпадения случайны
+const minPatternLength = 3
+
+// EstimateEntropy оценивает стойкость пароля в битах по мотивам zxcvbn.
+// Пароль разбирается слева направо на известные шаблоны: слова из списка
+// распространённых паролей (в том числе с заменами вроде @ вместо a), повторы
+// символов, последовательности (abc, 321), ряды клавиатуры и годы. Шаблон стоит
+// столько бит, сколько нужно, чтобы угадать его перебором по словарю или по шаблонам,
+// остальные символы - log2 размера алфавита пароля каждый.
+func EstimateEntropy(password string) float64 {
+	runes := []rune(password)
+	lower := []rune(strings.ToLower(password))
+	charBits := math.Log2(float64(alphabetSize(runes)))
+
+	var bits float64
+	for i := 0; i < len(runes); {
+		n, b := matchPattern(runes, lower, i, charBits)
+		if n == 0 {
+			bits += charBits
+			i++
+			continue
+		}
+		bits += b
+		i += n
+	}
+	return bits
+}
+
+// matchPattern находит самый длинный шаблон, начинающийся с позиции i.
+// Возвращает его длину и стоимость в битах; 0 - шаблона нет.
+func matchPattern(runes, lower []rune, i int, charBits float64) (int, float64) {
+	bestLen, bestBits := 0, 0.0
+	consider := func(n int, b float64) {
+		if n > bestLen || (n == bestLen && b < bestBits) {
+			bestLen, bestBits = n, b
+		}
+	}
+
+	if n, b := matchDictionary(runes, lower, i); n > 0 {
+		consider(n, b)
+	}
+	if n := repeatLength(lower, i); n >= minPatternLength {
+		consider(n, charBits+math.Log2(float64(n)))
+	}
+	if n, descending := sequenceLength(lower, i); n >= minPatternLength {
+		b := math.Log2(26) + math.Log2(float64(n))
+		if descending {
+			b++
+		}
+		consider(n, b)
+	}
+	if n := keyboardLength(lower, i); n >= minPatternLength+1 {
+		consider(n, math.Log2(float64(len(keyboardRows)*10))+math.Log2(float64(n)))
+	}
+	if isYear(lower, i) {
+		consider(4, math.Log2(200))
+	}
+	return bestLen, bestBits
+}
+
+// matchDictionary ищет самое длинное слово из списка распространённых паролей.
+// Стоимость - log2 ранга слова плюс бит за регистр и бит за подстановки.
+func matchDictionary(runes, lower []rune, i int) (int, float64) {
+	for end := len(lower); end-i >= minPatternLength+1; end-- {
+		word := string(lower[i:end])
+		unleeted := leetReplacer.Replace(word)
+		rank, ok := commonPasswordRank[unleeted]
+		if !ok {
+			continue
+		}
+
+		b := math.Log2(float64(rank + 1))
+		b += caseBits(runes[i:end])
+		if unleeted != word {
+			b++
+		}
+		return end - i, b
+	}
+	return 0, 0
+}
+
+// caseBits стоимость регистра букв: первая заглавная или все заглавные почти ничего не добавляют
+func caseBits(runes []rune) float64 {
+	var upper, letters int
+	for _, r := range runes {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	switch {
+	case upper == 0:
+		return 0
+	case upper == letters || (upper == 1 && unicode.IsUpper(runes[0])):
+		return 1
+	default:
+		return float64(upper)
+	}
+}
+
+func repeatLength(lower []rune, i int) int {
+	n := 1
+	for i+n < len(lower) && lower[i+n] == lower[i] {
+		n++
+	}
+	return n
+}
+
+// sequenceLength длина последовательности с шагом 1 или -1 (abc, 4321)
+func sequenceLength(lower []rune, i int) (int, bool) {
+	if i+1 >= len(lower) {
+		return 1, false
+	}
+	delta := lower[i+1] - lower[i]
+	if delta != 1 && delta != -1 {
+		return 1, false
+	}
+	n := 2
+	for i+n < len(lower) && lower[i+n]-lower[i+n-1] == delta {
+		n++
+	}
+	return n, delta == -1
+}
+
+// keyboardLength длина подряд идущих клавиш одного ряда в любом направлении
+func keyboardLength(lower []rune, i int) int {
+	best := 0
+	for _, row := range keyboardRows {
+		for _, r := range []string{row, reverse(row)} {
+			n := 0
+			for i+n < len(lower) && strings.Contains(r, string(lower[i:i+n+1])) {
+				n++
+			}
+			if n > best {
+				best = n
+			}
+		}
+	}
+	return best
+}
+
+func isYear(lower []rune, i int) bool {
+	if i+4 > len(lower) {
+		return false
+	}
+	s := string(lower[i : i+4])
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s >= "1900" && s <= "2099"
+}
+
+// alphabetSize размер алфавита, из которого набран пароль
+func alphabetSize(runes []rune) int {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	size := 0
+	if lower {
+		size += 26
+	}
+	if upper {
+		size += 26
+	}
+	if digit {
+		size += 10
+	}
+	if symbol {
+		size += 33
+	}
+	if other {
+		size += 100
+	}
+	if size == 0 {
+		size = 1
+	}
+	return size
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
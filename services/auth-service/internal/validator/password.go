@@ -0,0 +1,181 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Коды нарушений политики паролей. Коды стабильны: клиент может показывать по ним
+// собственные сообщения.
+const (
+	ViolationTooShort      = "too_short"
+	ViolationTooLong       = "too_long"
+	ViolationMissingUpper  = "missing_uppercase"
+	ViolationMissingLower  = "missing_lowercase"
+	ViolationMissingDigit  = "missing_digit"
+	ViolationMissingSymbol = "missing_symbol"
+	ViolationContainsEmail = "contains_email"
+	ViolationTooGuessable  = "too_guessable"
+	ViolationCommon        = "common_password"
+	ViolationBreached      = "breached_password"
+)
+
+// Violation нарушение политики паролей
+type Violation struct {
+	Code    string
+	Message string
+}
+
+// PolicyError перечисляет все нарушения политики, а не только первое,
+// чтобы пользователь мог исправить пароль за одну попытку
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Is позволяет проверять нарушение через errors.Is(err, ErrPasswordTooWeak),
+// а слишком короткий пароль - ещё и через ErrPasswordTooShort
+func (e *PolicyError) Is(target error) bool {
+	if target == ErrPasswordTooWeak {
+		return true
+	}
+	if target == ErrPasswordTooShort {
+		for _, v := range e.Violations {
+			if v.Code == ViolationTooShort {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// BreachChecker проверяет, встречался ли пароль в утечках
+type BreachChecker interface {
+	IsBreached(password string) (bool, error)
+}
+
+// PasswordPolicy требования к паролю
+type PasswordPolicy struct {
+	// MinLength и MaxLength ограничивают длину в символах.
+	// Верхняя граница защищает от дорогого хеширования огромных строк.
+	MinLength int
+	MaxLength int
+	// Требования к классам символов. По умолчанию выключены: длина и оценка
+	// стойкости отсекают слабые пароли лучше, чем правила состава.
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// ForbidEmail запрещает пароль, содержащий имя из email (часть до @)
+	ForbidEmail bool
+	// MinEntropyBits минимальная оценка стойкости (см. EstimateEntropy); 0 - без проверки
+	MinEntropyBits float64
+	// ForbidCommon запрещает пароли из встроенного списка самых распространённых
+	ForbidCommon bool
+	// Breached проверка по базе утечек; nil - без проверки
+	Breached BreachChecker
+}
+
+// DefaultPasswordPolicy возвращает политику по умолчанию: от 8 до 128 символов,
+// без email, не из списка распространённых и со стойкостью не меньше 30 бит
+func DefaultPasswordPolicy() *PasswordPolicy {
+	return &PasswordPolicy{
+		MinLength:      8,
+		MaxLength:      128,
+		ForbidEmail:    true,
+		MinEntropyBits: 30,
+		ForbidCommon:   true,
+	}
+}
+
+// Check проверяет пароль пользователя с указанным email (может быть пустым).
+// Нарушения возвращаются как *PolicyError; другие ошибки означают сбой проверки по базе утечек.
+func (p *PasswordPolicy) Check(password, email string) error {
+	var violations []Violation
+	add := func(code, format string, args ...any) {
+		violations = append(violations, Violation{Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		add(ViolationTooShort, "password too short (min %d chars)", p.MinLength)
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		add(ViolationTooLong, "password too long (max %d chars)", p.MaxLength)
+		// Остальные проверки для такой строки бессмысленны и дороги
+		return &PolicyError{Violations: violations}
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+	if p.RequireUpper && !hasUpper {
+		add(ViolationMissingUpper, "password must contain an uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		add(ViolationMissingLower, "password must contain a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		add(ViolationMissingDigit, "password must contain a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		add(ViolationMissingSymbol, "password must contain a symbol")
+	}
+
+	if p.ForbidEmail && containsEmailName(password, email) {
+		add(ViolationContainsEmail, "password must not contain your email")
+	}
+
+	common := p.ForbidCommon && IsCommonPassword(password)
+	if common {
+		add(ViolationCommon, "password is too common")
+	}
+	// Для распространённого пароля оценка стойкости ничего не добавит
+	if !common && p.MinEntropyBits > 0 && EstimateEntropy(password) < p.MinEntropyBits {
+		add(ViolationTooGuessable, "password is too easy to guess")
+	}
+
+	if p.Breached != nil && !common {
+		breached, err := p.Breached.IsBreached(password)
+		if err != nil {
+			return fmt.Errorf("check breached passwords: %w", err)
+		}
+		if breached {
+			add(ViolationBreached, "password has appeared in a data breach")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// containsEmailName проверяет, содержит ли пароль часть email до @.
+// Слишком короткие имена не проверяются: они случайно совпадают с частью пароля.
+func containsEmailName(password, email string) bool {
+	name, _, ok := strings.Cut(strings.ToLower(email), "@")
+	if !ok || utf8.RuneCountInString(name) < 3 {
+		return false
+	}
+	return strings.Contains(strings.ToLower(password), name)
+}
@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func violationCodes(err error) []string {
+	var policyErr *PolicyError
+	if !errors.As(err, &policyErr) {
+		return nil
+	}
+	var codes []string
+	for _, v := range policyErr.Violations {
+		codes = append(codes, v.Code)
+	}
+	return codes
+}
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	strict := DefaultPasswordPolicy()
+	strict.RequireUpper = true
+	strict.RequireDigit = true
+	strict.RequireSymbol = true
+
+	tests := []struct {
+		name     string
+		policy   *PasswordPolicy
+		password string
+		email    string
+		want     []string
+	}{
+		{"strong", DefaultPasswordPolicy(), "correct horse battery staple", "user@example.com", nil},
+		{"too short", DefaultPasswordPolicy(), "Zq9!", "", []string{ViolationTooShort, ViolationTooGuessable}},
+		{"too long", DefaultPasswordPolicy(), strings.Repeat("x", 129), "", []string{ViolationTooLong}},
+		{"common", DefaultPasswordPolicy(), "Password1", "", []string{ViolationCommon}},
+		{"leet and digits", DefaultPasswordPolicy(), "P@ssw0rd2024", "", []string{ViolationTooGuessable}},
+		{"keyboard", DefaultPasswordPolicy(), "qwertyuiop123", "", []string{ViolationTooGuessable}},
+		{"sequence", DefaultPasswordPolicy(), "abcdefgh12345678", "", []string{ViolationTooGuessable}},
+		{"contains email", DefaultPasswordPolicy(), "johnsmith-Kx81!vq", "JohnSmith@example.com", []string{ViolationContainsEmail}},
+		{"character classes", strict, "correct horse battery staple", "", []string{ViolationMissingUpper, ViolationMissingDigit}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.password, tt.email)
+			got := violationCodes(err)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Check(%q) violations = %v, want %v (entropy %.1f)", tt.password, got, tt.want, EstimateEntropy(tt.password))
+			}
+			if len(tt.want) > 0 && !errors.Is(err, ErrPasswordTooWeak) {
+				t.Errorf("Check(%q) error is not ErrPasswordTooWeak", tt.password)
+			}
+		})
+	}
+
+	if err := ValidatePassword("short"); !errors.Is(err, ErrPasswordTooShort) {
+		t.Errorf("ValidatePassword(short) = %v, want ErrPasswordTooShort", err)
+	}
+}
+
+func TestPwnedRangeFile(t *testing.T) {
+	sha := func(s string) string {
+		sum := sha1.Sum([]byte(s))
+		return strings.ToUpper(hex.EncodeToString(sum[:]))
+	}
+
+	var lines []string
+	for _, p := range []string{"hunter2", "letmein", "tr0ub4dor&3", "monkey", "sunshine"} {
+		lines = append(lines, sha(p)+":42")
+	}
+	sort.Strings(lines)
+
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\r\n")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	breached, err := NewPwnedRangeFile(path)
+	if err != nil {
+		t.Fatalf("NewPwnedRangeFile() error = %v", err)
+	}
+
+	for _, p := range []string{"hunter2", "letmein", "tr0ub4dor&3", "monkey", "sunshine"} {
+		ok, err := breached.IsBreached(p)
+		if err != nil || !ok {
+			t.Errorf("IsBreached(%q) = %v, %v, want true", p, ok, err)
+		}
+	}
+	ok, err := breached.IsBreached("correct horse battery staple")
+	if err != nil || ok {
+		t.Errorf("IsBreached(unknown) = %v, %v, want false", ok, err)
+	}
+
+	policy := DefaultPasswordPolicy()
+	policy.Breached = breached
+	if got := violationCodes(policy.Check("tr0ub4dor&3", "")); strings.Join(got, ",") != ViolationBreached {
+		t.Errorf("Check(breached) violations = %v", got)
+	}
+}
@@ -3,15 +3,24 @@ package validator
 import (
 	"errors"
 	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/idna"
 )
 
 var (
 	ErrInvalidEmail     = errors.New("invalid email format")
 	ErrPasswordTooShort = errors.New("password too short (min 8 chars)")
 	ErrPasswordTooWeak  = errors.New("password too weak")
+	ErrInvalidRole      = errors.New("invalid role name")
+	ErrInvalidUserID    = errors.New("invalid user id")
 )
 
-var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
+// emailRegex допускает домен верхнего уровня из букв или в punycode (xn--p1ai для .рф)
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.([a-zA-Z]{2,}|xn--[a-zA-Z0-9\-]+)$`)
+
+var roleRegex = regexp.MustCompile(`^[a-z][a-z0-9_\-]{0,31}$`)
 
 // ValidateEmail проверяет формат email
 func ValidateEmail(email string) error {
@@ -24,11 +33,46 @@ func ValidateEmail(email string) error {
 	return nil
 }
 
-// ValidatePassword проверяет требования к паролю
+// NormalizeEmail приводит email к каноническому виду и проверяет его формат.
+// Пробелы по краям отбрасываются, домен переводится в нижний регистр, а
+// интернационализированный домен - в punycode (пример.рф -> xn--e1afmkfd.xn--p1ai).
+// Регистр локальной части сохраняется; email сравнивается без учёта регистра в базе.
+func NormalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return "", ErrInvalidEmail
+	}
+
+	domain, err := idna.Lookup.ToASCII(email[at+1:])
+	if err != nil {
+		return "", ErrInvalidEmail
+	}
+
+	email = email[:at+1] + domain
+	if err := ValidateEmail(email); err != nil {
+		return "", err
+	}
+	return email, nil
+}
+
+// ValidatePassword проверяет пароль по политике по умолчанию (см. DefaultPasswordPolicy)
 func ValidatePassword(password string) error {
-	if len(password) < 8 {
-		return ErrPasswordTooShort
+	return DefaultPasswordPolicy().Check(password, "")
+}
+
+// ValidateRole проверяет имя роли: строчные латинские буквы, цифры, "_" и "-", до 32 символов
+func ValidateRole(role string) error {
+	if !roleRegex.MatchString(role) {
+		return ErrInvalidRole
+	}
+	return nil
+}
+
+// ValidateUserID проверяет, что ID пользователя - UUID
+func ValidateUserID(userID string) error {
+	if _, err := uuid.Parse(userID); err != nil {
+		return ErrInvalidUserID
 	}
-	// Можно добавить проверку на сложность (цифры, спецсимволы)
 	return nil
-}
\ No newline at end of file
+}
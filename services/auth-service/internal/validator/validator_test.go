@@ -0,0 +1,47 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		email   string
+		want    string
+		wantErr bool
+	}{
+		{"already normalized", "bob@example.com", "bob@example.com", false},
+		{"surrounding spaces", "  bob@example.com\t", "bob@example.com", false},
+		{"domain case", "bob@Example.COM", "bob@example.com", false},
+		{"local part case kept", "Bob.Smith@example.com", "Bob.Smith@example.com", false},
+		{"idn domain", "bob@пример.рф", "bob@xn--e1afmkfd.xn--p1ai", false},
+		{"idn domain case", "bob@Bücher.DE", "bob@xn--bcher-kva.de", false},
+		{"punycode domain", "bob@xn--e1afmkfd.xn--p1ai", "bob@xn--e1afmkfd.xn--p1ai", false},
+		{"empty", "", "", true},
+		{"no at", "bob.example.com", "", true},
+		{"no local part", "@example.com", "", true},
+		{"double at", "bob@@example.com", "", true},
+		{"no tld", "bob@localhost", "", true},
+		{"invalid domain", "bob@exa_mple.com", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeEmail(tt.email)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidEmail) {
+					t.Errorf("NormalizeEmail(%q) error = %v, want ErrInvalidEmail", tt.email, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeEmail(%q) error = %v", tt.email, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeEmail(%q) = %q, want %q", tt.email, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+CREATE TABLE revoked_users (
+    user_id UUID PRIMARY KEY,
+    issued_before TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_revoked_users_expires_at ON revoked_users (expires_at);
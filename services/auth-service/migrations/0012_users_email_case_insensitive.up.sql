@@ -0,0 +1,11 @@
+-- Email уникален без учёта регистра. Если миграция падает на создании индекса,
+-- в базе есть адреса, различающиеся только регистром: найдите их
+-- скриптом scripts/find-email-collisions.sh и объедините или переименуйте.
+ALTER TABLE users DROP CONSTRAINT users_email_key;
+
+-- Домен приводится к нижнему регистру, как делает validator.NormalizeEmail
+UPDATE users
+SET email = split_part(email, '@', 1) || '@' || lower(split_part(email, '@', 2))
+WHERE email <> split_part(email, '@', 1) || '@' || lower(split_part(email, '@', 2));
+
+CREATE UNIQUE INDEX users_email_lower_key ON users (lower(email));
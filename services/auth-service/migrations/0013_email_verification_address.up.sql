@@ -0,0 +1,4 @@
+-- Адрес, на который отправлен токен. Если он отличается от текущего email пользователя
+-- (смена email), адрес применяется только при подтверждении.
+-- NULL у токенов, выданных до миграции: они подтверждают текущий email.
+ALTER TABLE email_verification_tokens ADD COLUMN email TEXT NULL;
@@ -0,0 +1,9 @@
+// Package migrations содержит SQL миграции auth-service, встроенные в бинарник
+package migrations
+
+import "embed"
+
+// FS файлы миграций вида NNNN_name.up.sql и NNNN_name.down.sql
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,82 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"golang-project/pkg/migrate"
+)
+
+func TestFS(t *testing.T) {
+	got, err := migrate.Load(FS)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(got) == 0 {
+		t.Fatal("Load() returned no migrations")
+	}
+	for i, m := range got {
+		if m.Version != uint64(i+1) {
+			t.Errorf("migration %d_%s: versions must go without gaps, want %d", m.Version, m.Name, i+1)
+		}
+		if m.Down == "" {
+			t.Errorf("migration %d_%s has no down file", m.Version, m.Name)
+		}
+	}
+}
+
+// TestFS_UpDown применяет все миграции и откатывает их обратно.
+// Тест пропускается, если DB_DSN не задан.
+func TestFS_UpDown(t *testing.T) {
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		t.Skip("DB_DSN is not set, skipping Postgres test")
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer admin.Close()
+
+	schema := fmt.Sprintf("migrations_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	defer admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	db, err := sql.Open("postgres", dsn+sep+"search_path="+schema)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	m, err := migrate.New(db, FS)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if reverted, err := m.Down(ctx, applied); err != nil || reverted != applied {
+		t.Fatalf("Down(%d) = %d, %v, want %d, nil", applied, reverted, err, applied)
+	}
+	// После отката миграции применяются заново без ошибок
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up() after Down error = %v", err)
+	}
+}
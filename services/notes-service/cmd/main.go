@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	_ "github.com/lib/pq"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	notesv1 "golang-project/api/proto/gen/go/notes/v1"
+	"golang-project/pkg/config"
+	"golang-project/pkg/database"
+	"golang-project/pkg/migrate"
+	"golang-project/pkg/outbox"
+	"golang-project/services/notes-service/internal/consumer"
+	"golang-project/services/notes-service/internal/repo"
+	"golang-project/services/notes-service/internal/service"
+	"golang-project/services/notes-service/migrations"
+)
+
+func main() {
+	cfg := config.Load()
+
+	// Подключение к БД: при старте база может быть ещё недоступна, ждём её до DBConnectTimeout
+	db, err := database.Open(context.Background(), "postgres", cfg.DBDSN, database.Config{
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+		ConnMaxIdleTime: cfg.DBConnMaxIdleTime,
+		ConnectTimeout:  cfg.DBConnectTimeout,
+	})
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Миграции встроены в бинарник: notes-service migrate up|down [N]|status
+	migrator, err := migrate.New(db, migrations.FS)
+	if err != nil {
+		log.Fatalf("failed to load migrations: %v", err)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate.Command(context.Background(), migrator, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		return
+	}
+
+	// Без общего секрета приём событий был бы открыт любому, кто достучится до EventsAddr
+	if cfg.EventsToken == "" {
+		log.Fatalf("EVENTS_TOKEN is required")
+	}
+
+	if cfg.AutoMigrate {
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			log.Fatalf("failed to apply migrations: %v", err)
+		}
+		log.Printf("migrations applied: %d", applied)
+	}
+
+	// Инициализация зависимостей
+	noteRepo := repo.NewNoteRepo(db)
+	notesService := service.NewNotesServer(noteRepo)
+
+	// Запуск gRPC сервера
+	lis, err := net.Listen("tcp", cfg.GRPCAddr)
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+
+	grpcServer := grpc.NewServer()
+	notesv1.RegisterNotesServiceServer(grpcServer, notesService)
+	reflection.Register(grpcServer)
+
+	go func() {
+		log.Printf("notes gRPC listening on %s", cfg.GRPCAddr)
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("failed to serve: %v", err)
+		}
+	}()
+
+	// HTTP сервер для приёма событий auth-service (удаление данных пользователя)
+	userEvents := consumer.NewUserEventsConsumer(noteRepo)
+	mux := http.NewServeMux()
+	mux.Handle(consumer.EventsPath, outbox.Handler(cfg.EventsToken, userEvents.Handle))
+	httpServer := &http.Server{
+		Addr:              cfg.EventsAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		log.Printf("notes events listening on %s", cfg.EventsAddr)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("failed to serve events: %v", err)
+		}
+	}()
+
+	// Graceful shutdown
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	<-sigCh
+
+	log.Println("shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	httpServer.Shutdown(shutdownCtx)
+	grpcServer.GracefulStop()
+}
@@ -0,0 +1,16 @@
+#!/bin/sh
+set -e
+
+# Готовности PostgreSQL сервис ждёт сам (DB_CONNECT_TIMEOUT),
+# миграции встроены в бинарник и применяются им при AUTO_MIGRATE=true
+echo "Starting notes-service..."
+
+# Запускаем сервис
+exec ./notes-service "$@"
+
+
+
+
+
+
+
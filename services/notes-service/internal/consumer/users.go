@@ -0,0 +1,50 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"golang-project/pkg/events"
+	"golang-project/pkg/outbox"
+	"golang-project/services/notes-service/internal/domain"
+)
+
+// EventsPath путь, по которому notes-service принимает события от auth-service
+const EventsPath = "/events"
+
+// UserEventsConsumer обрабатывает события пользователей из outbox auth-service
+type UserEventsConsumer struct {
+	notes domain.NoteRepository
+}
+
+// NewUserEventsConsumer создаёт обработчик событий пользователей
+func NewUserEventsConsumer(noteRepo domain.NoteRepository) *UserEventsConsumer {
+	return &UserEventsConsumer{notes: noteRepo}
+}
+
+// Handle обрабатывает одно событие. Неизвестные типы пропускаются.
+// События могут доставляться повторно, поэтому обработка идемпотентна.
+func (c *UserEventsConsumer) Handle(ctx context.Context, msg outbox.Message) error {
+	op := "UserEventsConsumer.Handle"
+
+	switch msg.Type {
+	case events.TypeUserDeleted:
+		var event events.UserDeleted
+		if err := json.Unmarshal(msg.Payload, &event); err != nil {
+			return fmt.Errorf("decode %s: %w", msg.Type, err)
+		}
+
+		deleted, err := c.notes.DeleteNotesByOwner(ctx, event.UserID)
+		if err != nil {
+			return fmt.Errorf("delete notes of user %s: %w", event.UserID, err)
+		}
+
+		slog.Info("user notes deleted", slog.String("op", op), slog.String("user_id", event.UserID), slog.Int64("count", deleted))
+	default:
+		slog.Debug("event skipped", slog.String("op", op), slog.String("type", msg.Type), slog.String("id", msg.ID))
+	}
+
+	return nil
+}
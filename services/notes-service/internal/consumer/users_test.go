@@ -0,0 +1,69 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"golang-project/pkg/events"
+	"golang-project/pkg/outbox"
+	"golang-project/services/notes-service/internal/notestest"
+)
+
+func TestUserEventsConsumer_Handle(t *testing.T) {
+	ctx := context.Background()
+	deletedUser := uuid.New().String()
+	otherUser := uuid.New().String()
+
+	store := notestest.NewStore()
+	for _, ownerID := range []string{deletedUser, deletedUser, otherUser} {
+		if _, err := store.CreateNote(ctx, ownerID, "title", "content"); err != nil {
+			t.Fatalf("CreateNote() error = %v", err)
+		}
+	}
+	// Помеченные удалёнными заметки тоже удаляются окончательно
+	note, err := store.CreateNote(ctx, deletedUser, "trash", "")
+	if err != nil {
+		t.Fatalf("CreateNote() error = %v", err)
+	}
+	if err := store.DeleteNote(ctx, note.ID, deletedUser); err != nil {
+		t.Fatalf("DeleteNote() error = %v", err)
+	}
+
+	payload, err := json.Marshal(events.UserDeleted{UserID: deletedUser})
+	if err != nil {
+		t.Fatalf("failed to encode event: %v", err)
+	}
+	msg := outbox.Message{ID: uuid.New().String(), AggregateID: deletedUser, Type: events.TypeUserDeleted, Payload: payload}
+
+	consumer := NewUserEventsConsumer(store)
+	if err := consumer.Handle(ctx, msg); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if n := store.Count(deletedUser); n != 0 {
+		t.Errorf("notes of deleted user = %d, want 0", n)
+	}
+	if n := store.Count(otherUser); n != 1 {
+		t.Errorf("notes of other user = %d, want 1", n)
+	}
+
+	// Повторная доставка того же события безопасна
+	if err := consumer.Handle(ctx, msg); err != nil {
+		t.Errorf("Handle() redelivery error = %v", err)
+	}
+
+	// Неизвестные события пропускаются, битые - возвращают ошибку для повторной доставки
+	created := outbox.Message{ID: uuid.New().String(), Type: events.TypeUserCreated, Payload: []byte(`{}`)}
+	if err := consumer.Handle(ctx, created); err != nil {
+		t.Errorf("Handle(%s) error = %v, want nil", created.Type, err)
+	}
+	broken := outbox.Message{ID: uuid.New().String(), Type: events.TypeUserDeleted, Payload: []byte(`{`)}
+	if err := consumer.Handle(ctx, broken); err == nil {
+		t.Error("Handle() with invalid payload error = nil, want error")
+	}
+	if n := store.Count(otherUser); n != 1 {
+		t.Errorf("notes of other user after other events = %d, want 1", n)
+	}
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// NoteRepository — интерфейс для работы с заметками
+type NoteRepository interface {
+	CreateNote(ctx context.Context, ownerID, title, content string) (*Note, error)
+	GetNote(ctx context.Context, id, ownerID string) (*Note, error)
+	ListNotes(ctx context.Context, ownerID string, limit, offset int) ([]*Note, int, error)
+	UpdateNote(ctx context.Context, id, ownerID, title, content string) (*Note, error)
+	DeleteNote(ctx context.Context, id, ownerID string) error
+	DeleteNotesByOwner(ctx context.Context, ownerID string) (int64, error)
+}
+
+// Note — доменная модель заметки
+type Note struct {
+	ID        string
+	OwnerID   string
+	Title     string
+	Content   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
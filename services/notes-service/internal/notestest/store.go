@@ -0,0 +1,170 @@
+// Package notestest содержит in-memory реализацию хранилища заметок для тестов без PostgreSQL.
+// Реализация повторяет поведение пакета repo, включая его ошибки и порядок выдачи.
+package notestest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang-project/services/notes-service/internal/domain"
+	"golang-project/services/notes-service/internal/repo"
+)
+
+// Store хранит заметки в памяти процесса и реализует domain.NoteRepository
+type Store struct {
+	mu    sync.Mutex
+	notes map[string]*note
+	now   func() time.Time
+}
+
+type note struct {
+	domain.Note
+	deletedAt *time.Time
+}
+
+// NewStore создаёт пустое хранилище
+func NewStore() *Store {
+	return &Store{
+		notes: make(map[string]*note),
+		now:   time.Now,
+	}
+}
+
+// Count возвращает количество заметок владельца, включая помеченные удалёнными
+func (s *Store) Count(ownerID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for _, n := range s.notes {
+		if n.OwnerID == ownerID {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Store) CreateNote(ctx context.Context, ownerID, title, content string) (*domain.Note, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Метки времени строго растут, чтобы порядок выдачи в тестах был однозначным
+	now := s.now()
+	for _, n := range s.notes {
+		if !n.CreatedAt.Before(now) {
+			now = n.CreatedAt.Add(time.Microsecond)
+		}
+	}
+
+	n := &note{Note: domain.Note{
+		ID:        uuid.New().String(),
+		OwnerID:   ownerID,
+		Title:     title,
+		Content:   content,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}}
+	s.notes[n.ID] = n
+	c := n.Note
+	return &c, nil
+}
+
+// GetNote возвращает заметку только если она принадлежит ownerID
+func (s *Store) GetNote(ctx context.Context, id, ownerID string) (*domain.Note, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := s.find(id, ownerID)
+	if n == nil {
+		return nil, repo.ErrNoteNotFound
+	}
+	c := n.Note
+	return &c, nil
+}
+
+// ListNotes возвращает страницу заметок владельца от новых к старым и их общее количество
+func (s *Store) ListNotes(ctx context.Context, ownerID string, limit, offset int) ([]*domain.Note, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var owned []*domain.Note
+	for _, n := range s.notes {
+		if n.OwnerID == ownerID && n.deletedAt == nil {
+			c := n.Note
+			owned = append(owned, &c)
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		if !owned[i].CreatedAt.Equal(owned[j].CreatedAt) {
+			return owned[i].CreatedAt.After(owned[j].CreatedAt)
+		}
+		return owned[i].ID < owned[j].ID
+	})
+
+	total := len(owned)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return owned[offset:end], total, nil
+}
+
+func (s *Store) UpdateNote(ctx context.Context, id, ownerID, title, content string) (*domain.Note, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := s.find(id, ownerID)
+	if n == nil {
+		return nil, repo.ErrNoteNotFound
+	}
+	n.Title = title
+	n.Content = content
+	n.UpdatedAt = s.now()
+	c := n.Note
+	return &c, nil
+}
+
+// DeleteNote помечает заметку удалённой (soft delete)
+func (s *Store) DeleteNote(ctx context.Context, id, ownerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := s.find(id, ownerID)
+	if n == nil {
+		return repo.ErrNoteNotFound
+	}
+	now := s.now()
+	n.deletedAt = &now
+	return nil
+}
+
+// DeleteNotesByOwner безвозвратно удаляет все заметки пользователя, включая помеченные удалёнными
+func (s *Store) DeleteNotesByOwner(ctx context.Context, ownerID string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for id, n := range s.notes {
+		if n.OwnerID == ownerID {
+			delete(s.notes, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// find ищет неудалённую заметку владельца
+func (s *Store) find(id, ownerID string) *note {
+	n, ok := s.notes[id]
+	if !ok || n.OwnerID != ownerID || n.deletedAt != nil {
+		return nil
+	}
+	return n
+}
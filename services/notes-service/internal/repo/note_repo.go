@@ -0,0 +1,184 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+
+	"golang-project/services/notes-service/internal/domain"
+)
+
+var (
+	ErrNoteNotFound = errors.New("note not found")
+)
+
+type NoteRepo struct {
+	db *sql.DB
+}
+
+func NewNoteRepo(db *sql.DB) *NoteRepo {
+	return &NoteRepo{db: db}
+}
+
+func (r *NoteRepo) CreateNote(ctx context.Context, ownerID, title, content string) (*domain.Note, error) {
+	var note domain.Note
+
+	query := `
+		INSERT INTO notes (id, owner_id, title, content, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW())
+		RETURNING id, owner_id, title, content, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, uuid.New().String(), ownerID, title, content).Scan(
+		&note.ID,
+		&note.OwnerID,
+		&note.Title,
+		&note.Content,
+		&note.CreatedAt,
+		&note.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &note, nil
+}
+
+// GetNote возвращает заметку только если она принадлежит ownerID
+func (r *NoteRepo) GetNote(ctx context.Context, id, ownerID string) (*domain.Note, error) {
+	var note domain.Note
+
+	query := `
+		SELECT id, owner_id, title, content, created_at, updated_at
+		FROM notes
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+	`
+
+	err := r.db.QueryRowContext(ctx, query, id, ownerID).Scan(
+		&note.ID,
+		&note.OwnerID,
+		&note.Title,
+		&note.Content,
+		&note.CreatedAt,
+		&note.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrNoteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &note, nil
+}
+
+// ListNotes возвращает страницу заметок владельца и их общее количество
+func (r *NoteRepo) ListNotes(ctx context.Context, ownerID string, limit, offset int) ([]*domain.Note, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM notes WHERE owner_id = $1 AND deleted_at IS NULL`
+
+	if err := r.db.QueryRowContext(ctx, countQuery, ownerID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, owner_id, title, content, created_at, updated_at
+		FROM notes
+		WHERE owner_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, ownerID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	notes := make([]*domain.Note, 0, limit)
+	for rows.Next() {
+		var note domain.Note
+		if err := rows.Scan(
+			&note.ID,
+			&note.OwnerID,
+			&note.Title,
+			&note.Content,
+			&note.CreatedAt,
+			&note.UpdatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		notes = append(notes, &note)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return notes, total, nil
+}
+
+func (r *NoteRepo) UpdateNote(ctx context.Context, id, ownerID, title, content string) (*domain.Note, error) {
+	var note domain.Note
+
+	query := `
+		UPDATE notes
+		SET title = $3, content = $4, updated_at = NOW()
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+		RETURNING id, owner_id, title, content, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, id, ownerID, title, content).Scan(
+		&note.ID,
+		&note.OwnerID,
+		&note.Title,
+		&note.Content,
+		&note.CreatedAt,
+		&note.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrNoteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &note, nil
+}
+
+// DeleteNote помечает заметку удалённой (soft delete)
+func (r *NoteRepo) DeleteNote(ctx context.Context, id, ownerID string) error {
+	query := `
+		UPDATE notes
+		SET deleted_at = NOW()
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+	`
+
+	res, err := r.db.ExecContext(ctx, query, id, ownerID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNoteNotFound
+	}
+
+	return nil
+}
+
+// DeleteNotesByOwner безвозвратно удаляет все заметки пользователя, включая помеченные удалёнными.
+// Используется при удалении аккаунта; повторный вызов безопасен.
+func (r *NoteRepo) DeleteNotesByOwner(ctx context.Context, ownerID string) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM notes WHERE owner_id = $1`, ownerID)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	notesv1 "golang-project/api/proto/gen/go/notes/v1"
+	"golang-project/services/notes-service/internal/domain"
+	"golang-project/services/notes-service/internal/repo"
+	"golang-project/services/notes-service/internal/validator"
+)
+
+type NotesServer struct {
+	notesv1.UnimplementedNotesServiceServer
+	repo domain.NoteRepository
+}
+
+// NewNotesServer создаёт сервис заметок. Хранилище передаётся интерфейсом,
+// поэтому в тестах его заменяют реализации из пакета notestest.
+func NewNotesServer(noteRepo domain.NoteRepository) *NotesServer {
+	slog.Info("creating notes service")
+	return &NotesServer{
+		repo: noteRepo,
+	}
+}
+
+// CreateNote создаёт заметку пользователя
+func (s *NotesServer) CreateNote(ctx context.Context, req *notesv1.CreateNoteRequest) (*notesv1.CreateNoteResponse, error) {
+	op := "CreateNote"
+
+	slog.Info("create note", slog.String("op", op), slog.String("owner_id", req.OwnerId))
+
+	if err := validator.ValidateOwnerID(req.OwnerId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validator.ValidateNote(req.Title, req.Content); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	note, err := s.repo.CreateNote(ctx, req.OwnerId, req.Title, req.Content)
+	if err != nil {
+		slog.Error("failed to create note", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	slog.Info("note created", slog.String("op", op), slog.String("note_id", note.ID))
+
+	return &notesv1.CreateNoteResponse{Note: toProto(note)}, nil
+}
+
+// GetNote возвращает заметку владельца
+func (s *NotesServer) GetNote(ctx context.Context, req *notesv1.GetNoteRequest) (*notesv1.GetNoteResponse, error) {
+	op := "GetNote"
+
+	if err := validator.ValidateID(req.Id); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validator.ValidateOwnerID(req.OwnerId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Чужая заметка неотличима от несуществующей
+	note, err := s.repo.GetNote(ctx, req.Id, req.OwnerId)
+	if err == repo.ErrNoteNotFound {
+		return nil, status.Error(codes.NotFound, "note not found")
+	}
+	if err != nil {
+		slog.Error("failed to get note", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &notesv1.GetNoteResponse{Note: toProto(note)}, nil
+}
+
+// ListNotes возвращает страницу заметок владельца
+func (s *NotesServer) ListNotes(ctx context.Context, req *notesv1.ListNotesRequest) (*notesv1.ListNotesResponse, error) {
+	op := "ListNotes"
+
+	if err := validator.ValidateOwnerID(req.OwnerId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit, offset, err := validator.Pagination(req.Page, req.PageSize)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	notes, total, err := s.repo.ListNotes(ctx, req.OwnerId, limit, offset)
+	if err != nil {
+		slog.Error("failed to list notes", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	items := make([]*notesv1.Note, 0, len(notes))
+	for _, note := range notes {
+		items = append(items, toProto(note))
+	}
+
+	return &notesv1.ListNotesResponse{
+		Items: items,
+		Total: int32(total),
+	}, nil
+}
+
+// UpdateNote обновляет заголовок и содержимое заметки владельца
+func (s *NotesServer) UpdateNote(ctx context.Context, req *notesv1.UpdateNoteRequest) (*notesv1.UpdateNoteResponse, error) {
+	op := "UpdateNote"
+
+	slog.Info("update note", slog.String("op", op), slog.String("note_id", req.Id))
+
+	if err := validator.ValidateID(req.Id); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validator.ValidateOwnerID(req.OwnerId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validator.ValidateNote(req.Title, req.Content); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	note, err := s.repo.UpdateNote(ctx, req.Id, req.OwnerId, req.Title, req.Content)
+	if err == repo.ErrNoteNotFound {
+		return nil, status.Error(codes.NotFound, "note not found")
+	}
+	if err != nil {
+		slog.Error("failed to update note", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &notesv1.UpdateNoteResponse{Note: toProto(note)}, nil
+}
+
+// DeleteNote удаляет заметку владельца
+func (s *NotesServer) DeleteNote(ctx context.Context, req *notesv1.DeleteNoteRequest) (*notesv1.DeleteNoteResponse, error) {
+	op := "DeleteNote"
+
+	slog.Info("delete note", slog.String("op", op), slog.String("note_id", req.Id))
+
+	if err := validator.ValidateID(req.Id); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validator.ValidateOwnerID(req.OwnerId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err := s.repo.DeleteNote(ctx, req.Id, req.OwnerId)
+	if err == repo.ErrNoteNotFound {
+		return nil, status.Error(codes.NotFound, "note not found")
+	}
+	if err != nil {
+		slog.Error("failed to delete note", slog.String("op", op), slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &notesv1.DeleteNoteResponse{Ok: true}, nil
+}
+
+// toProto конвертирует доменную модель в protobuf
+func toProto(note *domain.Note) *notesv1.Note {
+	return &notesv1.Note{
+		Id:       note.ID,
+		OwnerId:  note.OwnerID,
+		Title:    note.Title,
+		Content:  note.Content,
+		CreateAt: note.CreatedAt.Unix(),
+		UpdateAt: note.UpdatedAt.Unix(),
+	}
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	notesv1 "golang-project/api/proto/gen/go/notes/v1"
+	"golang-project/services/notes-service/internal/notestest"
+	"golang-project/services/notes-service/internal/validator"
+)
+
+var (
+	alice = uuid.New().String()
+	bob   = uuid.New().String()
+)
+
+// testEnv сервис на in-memory хранилище
+type testEnv struct {
+	server *NotesServer
+	store  *notestest.Store
+}
+
+func newTestEnv() *testEnv {
+	store := notestest.NewStore()
+	return &testEnv{server: NewNotesServer(store), store: store}
+}
+
+// createNote создаёт заметку владельца через сервис
+func (env *testEnv) createNote(t *testing.T, ownerID, title string) *notesv1.Note {
+	t.Helper()
+
+	resp, err := env.server.CreateNote(context.Background(), &notesv1.CreateNoteRequest{
+		OwnerId: ownerID,
+		Title:   title,
+		Content: "content of " + title,
+	})
+	if err != nil {
+		t.Fatalf("CreateNote() error = %v", err)
+	}
+	return resp.Note
+}
+
+func TestNotesServer_CreateNote(t *testing.T) {
+	tests := []struct {
+		name     string
+		ownerID  string
+		title    string
+		content  string
+		wantCode codes.Code
+	}{
+		{name: "ok", ownerID: alice, title: "Shopping", content: "milk"},
+		{name: "empty content", ownerID: alice, title: "Title only"},
+		{name: "invalid owner", ownerID: "alice", title: "Shopping", wantCode: codes.InvalidArgument},
+		{name: "blank title", ownerID: alice, title: "   ", wantCode: codes.InvalidArgument},
+		{name: "title too long", ownerID: alice, title: strings.Repeat("я", validator.MaxTitleLength+1), wantCode: codes.InvalidArgument},
+		{name: "content too long", ownerID: alice, title: "Long", content: strings.Repeat("a", validator.MaxContentLength+1), wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv()
+
+			resp, err := env.server.CreateNote(context.Background(), &notesv1.CreateNoteRequest{
+				OwnerId: tt.ownerID,
+				Title:   tt.title,
+				Content: tt.content,
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("CreateNote() code = %v, want %v (err = %v)", code, tt.wantCode, err)
+			}
+			if tt.wantCode != codes.OK {
+				if n := env.store.Count(tt.ownerID); n != 0 {
+					t.Errorf("notes stored = %d, want 0", n)
+				}
+				return
+			}
+			if resp.Note.OwnerId != tt.ownerID || resp.Note.Title != tt.title || resp.Note.Content != tt.content {
+				t.Errorf("CreateNote() note = %+v, want owner %s, title %q, content %q", resp.Note, tt.ownerID, tt.title, tt.content)
+			}
+		})
+	}
+}
+
+func TestNotesServer_OwnerScoping(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	note := env.createNote(t, alice, "Private")
+
+	tests := []struct {
+		name string
+		call func(ownerID, id string) error
+	}{
+		{
+			name: "get",
+			call: func(ownerID, id string) error {
+				_, err := env.server.GetNote(ctx, &notesv1.GetNoteRequest{Id: id, OwnerId: ownerID})
+				return err
+			},
+		},
+		{
+			name: "update",
+			call: func(ownerID, id string) error {
+				_, err := env.server.UpdateNote(ctx, &notesv1.UpdateNoteRequest{Id: id, OwnerId: ownerID, Title: "Stolen"})
+				return err
+			},
+		},
+		{
+			name: "delete",
+			call: func(ownerID, id string) error {
+				_, err := env.server.DeleteNote(ctx, &notesv1.DeleteNoteRequest{Id: id, OwnerId: ownerID})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Чужая заметка неотличима от несуществующей
+			if code := status.Code(tt.call(bob, note.Id)); code != codes.NotFound {
+				t.Errorf("%s by other owner code = %v, want NotFound", tt.name, code)
+			}
+			if code := status.Code(tt.call(alice, uuid.New().String())); code != codes.NotFound {
+				t.Errorf("%s of missing note code = %v, want NotFound", tt.name, code)
+			}
+			if code := status.Code(tt.call(alice, "not-a-uuid")); code != codes.InvalidArgument {
+				t.Errorf("%s with invalid id code = %v, want InvalidArgument", tt.name, code)
+			}
+		})
+	}
+
+	// Попытки bob не изменили заметку alice
+	resp, err := env.server.GetNote(ctx, &notesv1.GetNoteRequest{Id: note.Id, OwnerId: alice})
+	if err != nil {
+		t.Fatalf("GetNote() by owner error = %v", err)
+	}
+	if resp.Note.Title != "Private" {
+		t.Errorf("GetNote() title = %q, want %q", resp.Note.Title, "Private")
+	}
+
+	list, err := env.server.ListNotes(ctx, &notesv1.ListNotesRequest{OwnerId: bob})
+	if err != nil {
+		t.Fatalf("ListNotes() error = %v", err)
+	}
+	if list.Total != 0 || len(list.Items) != 0 {
+		t.Errorf("ListNotes() for other owner = %d items, total %d, want empty", len(list.Items), list.Total)
+	}
+}
+
+func TestNotesServer_UpdateDeleteNote(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	note := env.createNote(t, alice, "Draft")
+
+	updated, err := env.server.UpdateNote(ctx, &notesv1.UpdateNoteRequest{Id: note.Id, OwnerId: alice, Title: "Final", Content: "done"})
+	if err != nil {
+		t.Fatalf("UpdateNote() error = %v", err)
+	}
+	if updated.Note.Title != "Final" || updated.Note.Content != "done" {
+		t.Errorf("UpdateNote() note = %+v, want title Final, content done", updated.Note)
+	}
+	if _, err := env.server.UpdateNote(ctx, &notesv1.UpdateNoteRequest{Id: note.Id, OwnerId: alice, Title: ""}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("UpdateNote() with empty title code = %v, want InvalidArgument", status.Code(err))
+	}
+
+	if _, err := env.server.DeleteNote(ctx, &notesv1.DeleteNoteRequest{Id: note.Id, OwnerId: alice}); err != nil {
+		t.Fatalf("DeleteNote() error = %v", err)
+	}
+	// Удалённая заметка больше не видна, повторное удаление - NotFound
+	if _, err := env.server.GetNote(ctx, &notesv1.GetNoteRequest{Id: note.Id, OwnerId: alice}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetNote() after delete code = %v, want NotFound", status.Code(err))
+	}
+	if _, err := env.server.DeleteNote(ctx, &notesv1.DeleteNoteRequest{Id: note.Id, OwnerId: alice}); status.Code(err) != codes.NotFound {
+		t.Errorf("DeleteNote() again code = %v, want NotFound", status.Code(err))
+	}
+}
+
+func TestNotesServer_ListNotes(t *testing.T) {
+	env := newTestEnv()
+	const notes = 25
+	for i := 1; i <= notes; i++ {
+		env.createNote(t, alice, fmt.Sprintf("note %d", i))
+	}
+	env.createNote(t, bob, "bob's note")
+
+	tests := []struct {
+		name       string
+		page       int32
+		pageSize   int32
+		wantCode   codes.Code
+		wantTitles []string
+		wantItems  int
+	}{
+		{
+			name:      "defaults",
+			wantItems: validator.DefaultPageSize,
+		},
+		{
+			name:       "first page newest first",
+			page:       1,
+			pageSize:   3,
+			wantTitles: []string{"note 25", "note 24", "note 23"},
+		},
+		{
+			name:       "second page",
+			page:       2,
+			pageSize:   3,
+			wantTitles: []string{"note 22", "note 21", "note 20"},
+		},
+		{
+			name:       "last partial page",
+			page:       3,
+			pageSize:   10,
+			wantTitles: []string{"note 5", "note 4", "note 3", "note 2", "note 1"},
+		},
+		{
+			name:      "past the end",
+			page:      10,
+			pageSize:  10,
+			wantItems: 0,
+		},
+		{
+			name:      "max page size",
+			pageSize:  validator.MaxPageSize,
+			wantItems: notes,
+		},
+		{name: "page size above max", pageSize: validator.MaxPageSize + 1, wantCode: codes.InvalidArgument},
+		{name: "negative page size", pageSize: -1, wantCode: codes.InvalidArgument},
+		{name: "negative page", page: -1, wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := env.server.ListNotes(context.Background(), &notesv1.ListNotesRequest{
+				OwnerId:  alice,
+				Page:     tt.page,
+				PageSize: tt.pageSize,
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("ListNotes() code = %v, want %v (err = %v)", code, tt.wantCode, err)
+			}
+			if tt.wantCode != codes.OK {
+				return
+			}
+
+			if resp.Total != notes {
+				t.Errorf("ListNotes() total = %d, want %d", resp.Total, notes)
+			}
+			if tt.wantTitles != nil {
+				var titles []string
+				for _, item := range resp.Items {
+					titles = append(titles, item.Title)
+				}
+				if strings.Join(titles, ",") != strings.Join(tt.wantTitles, ",") {
+					t.Errorf("ListNotes() titles = %v, want %v", titles, tt.wantTitles)
+				}
+			} else if len(resp.Items) != tt.wantItems {
+				t.Errorf("ListNotes() items = %d, want %d", len(resp.Items), tt.wantItems)
+			}
+			for _, item := range resp.Items {
+				if item.OwnerId != alice {
+					t.Errorf("ListNotes() returned note of %s", item.OwnerId)
+				}
+			}
+		})
+	}
+}
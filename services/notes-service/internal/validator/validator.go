@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxTitleLength   = 200
+	MaxContentLength = 10000
+	DefaultPageSize  = 20
+	MaxPageSize      = 100
+)
+
+var (
+	ErrInvalidID       = errors.New("invalid id format")
+	ErrInvalidOwnerID  = errors.New("invalid owner_id format")
+	ErrEmptyTitle      = errors.New("title required")
+	ErrTitleTooLong    = errors.New("title too long (max 200 chars)")
+	ErrContentTooLong  = errors.New("content too long (max 10000 chars)")
+	ErrInvalidPage     = errors.New("page must be >= 0")
+	ErrInvalidPageSize = errors.New("page_size must be between 0 and 100")
+)
+
+// ValidateID проверяет идентификатор заметки
+func ValidateID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrInvalidID
+	}
+	return nil
+}
+
+// ValidateOwnerID проверяет идентификатор владельца
+func ValidateOwnerID(ownerID string) error {
+	if _, err := uuid.Parse(ownerID); err != nil {
+		return ErrInvalidOwnerID
+	}
+	return nil
+}
+
+// ValidateNote проверяет заголовок и содержимое заметки
+func ValidateNote(title, content string) error {
+	if strings.TrimSpace(title) == "" {
+		return ErrEmptyTitle
+	}
+	if utf8.RuneCountInString(title) > MaxTitleLength {
+		return ErrTitleTooLong
+	}
+	if utf8.RuneCountInString(content) > MaxContentLength {
+		return ErrContentTooLong
+	}
+	return nil
+}
+
+// Pagination проверяет параметры пагинации и возвращает limit/offset.
+// Нулевые значения заменяются дефолтами: page=1, page_size=DefaultPageSize.
+func Pagination(page, pageSize int32) (limit, offset int, err error) {
+	if page < 0 {
+		return 0, 0, ErrInvalidPage
+	}
+	if pageSize < 0 || pageSize > MaxPageSize {
+		return 0, 0, ErrInvalidPageSize
+	}
+	if page == 0 {
+		page = 1
+	}
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	return int(pageSize), int(page-1) * int(pageSize), nil
+}
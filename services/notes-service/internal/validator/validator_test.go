@@ -0,0 +1,35 @@
+package validator
+
+import "testing"
+
+func TestPagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		page       int32
+		pageSize   int32
+		wantLimit  int
+		wantOffset int
+		wantErr    error
+	}{
+		{name: "defaults", wantLimit: DefaultPageSize, wantOffset: 0},
+		{name: "first page", page: 1, pageSize: 10, wantLimit: 10, wantOffset: 0},
+		{name: "third page", page: 3, pageSize: 10, wantLimit: 10, wantOffset: 20},
+		{name: "default size on later page", page: 2, wantLimit: DefaultPageSize, wantOffset: DefaultPageSize},
+		{name: "max size", pageSize: MaxPageSize, wantLimit: MaxPageSize, wantOffset: 0},
+		{name: "size above max", pageSize: MaxPageSize + 1, wantErr: ErrInvalidPageSize},
+		{name: "negative size", pageSize: -1, wantErr: ErrInvalidPageSize},
+		{name: "negative page", page: -1, wantErr: ErrInvalidPage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, offset, err := Pagination(tt.page, tt.pageSize)
+			if err != tt.wantErr {
+				t.Fatalf("Pagination() error = %v, want %v", err, tt.wantErr)
+			}
+			if limit != tt.wantLimit || offset != tt.wantOffset {
+				t.Errorf("Pagination() = %d, %d, want %d, %d", limit, offset, tt.wantLimit, tt.wantOffset)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"testing"
+
+	"golang-project/pkg/migrate"
+)
+
+func TestFS(t *testing.T) {
+	got, err := migrate.Load(FS)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(got) == 0 {
+		t.Fatal("Load() returned no migrations")
+	}
+	for i, m := range got {
+		if m.Version != uint64(i+1) {
+			t.Errorf("migration %d_%s: versions must go without gaps, want %d", m.Version, m.Name, i+1)
+		}
+		if m.Down == "" {
+			t.Errorf("migration %d_%s has no down file", m.Version, m.Name)
+		}
+	}
+}
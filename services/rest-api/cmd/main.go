@@ -14,6 +14,7 @@ import (
 	"github.com/go-chi/cors"
 	httpSwagger "github.com/swaggo/http-swagger"
 
+	"golang-project/pkg/auth/jwt"
 	"golang-project/pkg/config"
 	"golang-project/pkg/logger"
 	"golang-project/services/rest-api/internal/client"
@@ -27,7 +28,7 @@ import (
 // @version         1.0
 // @description     REST API Gateway для микросервисной архитектуры на Go с gRPC
 // @description     
-// @description     Этот API предоставляет endpoints для аутентификации пользователей.
+// @description     Этот API предоставляет endpoints для аутентификации пользователей и работы с заметками.
 // @description     Backend построен на микросервисной архитектуре с использованием gRPC для межсервисного взаимодействия.
 
 // @contact.name   API Support
@@ -60,12 +61,53 @@ func main() {
 	}
 	defer authClient.Close()
 
+	notesClient, err := client.NewNotesClient(cfg.NotesGRPCAddr)
+	if err != nil {
+		slog.Error("failed to create notes client", "error", err)
+		os.Exit(1)
+	}
+	defer notesClient.Close()
+
+	// Проверка токенов: по умолчанию через AuthService.ValidateToken, который видит
+	// denylist и отзыв всех токенов пользователя (SignOut, сброс пароля, удаление).
+	// Локальная проверка по публичному ключу включается явно и принимает
+	// отозванные токены до истечения срока.
+	var tokenValidator custommw.TokenValidator
+	switch cfg.TokenValidation {
+	case "remote":
+		tokenValidator = custommw.NewRemoteValidator(authClient.Client, cfg.JWTAudience)
+		slog.Info("JWT tokens are verified by auth service")
+	case "local":
+		jwtVerifier, err := jwt.NewVerifierFromEnv()
+		if err != nil {
+			slog.Error("failed to create JWT verifier", "error", err)
+			os.Exit(1)
+		}
+		var opts []jwt.ValidateOption
+		if cfg.JWTAudience != "" {
+			opts = append(opts, jwt.WithRequiredAudience(cfg.JWTAudience))
+		}
+		tokenValidator = custommw.NewLocalValidator(jwtVerifier, opts...)
+		slog.Warn("JWT tokens are verified locally, revoked tokens are accepted until they expire")
+	default:
+		slog.Error("unknown token validation mode", "mode", cfg.TokenValidation)
+		os.Exit(1)
+	}
+
+	// IP клиента из заголовков прокси нужен auth-service для ограничения попыток входа,
+	// поэтому заголовки принимаются только от перечисленных прокси
+	trustedProxies, err := custommw.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		slog.Error("invalid TRUSTED_PROXIES", "error", err)
+		os.Exit(1)
+	}
+
 	// Создаём роутер
 	r := chi.NewRouter()
 
 	// Middleware
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
+	r.Use(custommw.RealIP(trustedProxies))
 	r.Use(custommw.Logger)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Timeout(30 * time.Second))
@@ -92,14 +134,55 @@ func main() {
 	))
 
 	// Handlers
-	authHandler := handlers.NewAuthHandler(authClient)
+	if cfg.ClientIPToken == "" {
+		slog.Warn("CLIENT_IP_TOKEN is not set, auth service limits login attempts by gateway address")
+	}
+	authHandler := handlers.NewAuthHandler(authClient, cfg.ClientIPToken)
+	notesHandler := handlers.NewNotesHandler(notesClient)
+	adminHandler := handlers.NewAdminHandler(authClient)
+	accountHandler := handlers.NewAccountHandler(authClient)
 
 	// Routes
 	r.Route("/api/v1", func(r chi.Router) {
 		r.Route("/auth", func(r chi.Router) {
 			r.Post("/signup", authHandler.SignUp)
 			r.Post("/signin", authHandler.SignIn)
+			r.Post("/refresh", authHandler.RefreshToken)
+			r.Post("/verify-email", authHandler.VerifyEmail)
+			r.Post("/resend-verification", authHandler.ResendVerification)
+			r.Post("/password-reset", authHandler.RequestPasswordReset)
+			r.Post("/password-reset/confirm", authHandler.ResetPassword)
+			r.Post("/mfa/verify", authHandler.VerifyMFA)
 			r.Get("/validate", authHandler.ValidateToken)
+			r.With(custommw.Auth(tokenValidator)).Post("/signout", authHandler.SignOut)
+		})
+
+		// Защищённые маршруты: ID пользователя доступен через custommw.UserIDFromContext
+		r.Group(func(r chi.Router) {
+			r.Use(custommw.Auth(tokenValidator))
+
+			r.Route("/notes", func(r chi.Router) {
+				r.Get("/", notesHandler.ListNotes)
+				r.Post("/", notesHandler.CreateNote)
+				r.Get("/{id}", notesHandler.GetNote)
+				r.Put("/{id}", notesHandler.UpdateNote)
+				r.Delete("/{id}", notesHandler.DeleteNote)
+			})
+
+			r.Route("/me", func(r chi.Router) {
+				r.Delete("/", accountHandler.DeleteAccount)
+				r.Put("/password", accountHandler.ChangePassword)
+				r.Put("/email", accountHandler.ChangeEmail)
+				r.Post("/mfa/totp", accountHandler.EnrollTOTP)
+				r.Post("/mfa/totp/confirm", accountHandler.ConfirmTOTP)
+			})
+
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(custommw.RequireRole("admin"))
+
+				r.Post("/users/{id}/roles", adminHandler.GrantRole)
+				r.Delete("/users/{id}/roles/{role}", adminHandler.RevokeRole)
+			})
 		})
 	})
 
@@ -22,9 +22,188 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/admin/users/{id}/roles": {
+            "post": {
+                "description": "Выдаёт пользователю роль. Роль попадёт в токен пользователя после его обновления",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["admin"],
+                "summary": "Выдача роли",
+                "parameters": [{
+                    "type": "string",
+                    "description": "ID пользователя",
+                    "name": "id",
+                    "in": "path",
+                    "required": true
+                },                 {
+                    "description": "Роль",
+                    "name": "request",
+                    "in": "body",
+                    "required": true,
+                    "schema": {"$ref": "#/definitions/handlers.GrantRoleRequest"}
+                }],
+                "responses": {
+                    "200": {
+                        "description": "Текущие роли пользователя",
+                        "schema": {"$ref": "#/definitions/handlers.UserRolesResponse"}
+                    },
+                    "400": {"description": "Невалидные данные", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Отсутствует или невалидный токен", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "403": {"description": "Требуется роль admin", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "404": {"description": "Пользователь или роль не найдены", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                },
+                "security": [{"BearerAuth": []}]
+            }
+        },
+        "/api/v1/admin/users/{id}/roles/{role}": {
+            "delete": {
+                "description": "Отзывает роль у пользователя. Собственную роль admin отозвать нельзя",
+                "produces": ["application/json"],
+                "tags": ["admin"],
+                "summary": "Отзыв роли",
+                "parameters": [{
+                    "type": "string",
+                    "description": "ID пользователя",
+                    "name": "id",
+                    "in": "path",
+                    "required": true
+                },                 {
+                    "type": "string",
+                    "description": "Роль",
+                    "name": "role",
+                    "in": "path",
+                    "required": true
+                }],
+                "responses": {
+                    "200": {
+                        "description": "Текущие роли пользователя",
+                        "schema": {"$ref": "#/definitions/handlers.UserRolesResponse"}
+                    },
+                    "400": {"description": "Невалидные данные", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Отсутствует или невалидный токен", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "403": {"description": "Требуется роль admin", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "404": {"description": "Пользователь не найден", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "409": {"description": "Попытка отозвать собственную роль admin", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                },
+                "security": [{"BearerAuth": []}]
+            }
+        },
+        "/api/v1/auth/mfa/verify": {
+            "post": {
+                "description": "Обменивает mfa_token из ответа на вход и код из приложения-аутентификатора или код восстановления на пару токенов. После пяти неверных кодов mfa_token гасится",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["auth"],
+                "summary": "Второй шаг входа",
+                "parameters": [{
+                    "description": "Токен второго шага и код",
+                    "name": "request",
+                    "in": "body",
+                    "required": true,
+                    "schema": {"$ref": "#/definitions/handlers.VerifyMFARequest"}
+                }],
+                "responses": {
+                    "200": {
+                        "description": "Успешный вход, токен выдан",
+                        "schema": {"$ref": "#/definitions/handlers.SignInResponse"}
+                    },
+                    "400": {"description": "Невалидные данные", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Неверный код или mfa_token невалиден, истёк или погашен", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "429": {"description": "Слишком много неудачных попыток, см. заголовок Retry-After", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                }
+            }
+        },
+        "/api/v1/auth/password-reset": {
+            "post": {
+                "description": "Отправляет на email письмо с одноразовым токеном сброса пароля. Ответ одинаков для любого email",
+                "consumes": ["application/json"],
+                "tags": ["auth"],
+                "summary": "Запрос сброса пароля",
+                "parameters": [{
+                    "description": "Email пользователя",
+                    "name": "request",
+                    "in": "body",
+                    "required": true,
+                    "schema": {"$ref": "#/definitions/handlers.PasswordResetRequest"}
+                }],
+                "responses": {
+                    "202": {"description": "Письмо отправлено, если email зарегистрирован"},
+                    "400": {"description": "Невалидные данные", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                }
+            }
+        },
+        "/api/v1/auth/password-reset/confirm": {
+            "post": {
+                "description": "Устанавливает новый пароль по токену из письма. Все сессии пользователя завершаются",
+                "consumes": ["application/json"],
+                "tags": ["auth"],
+                "summary": "Установка нового пароля",
+                "parameters": [{
+                    "description": "Токен из письма и новый пароль",
+                    "name": "request",
+                    "in": "body",
+                    "required": true,
+                    "schema": {"$ref": "#/definitions/handlers.PasswordResetConfirmRequest"}
+                }],
+                "responses": {
+                    "204": {"description": "Пароль изменён"},
+                    "400": {"description": "Невалидный пароль или токен невалиден, истёк или уже использован", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                }
+            }
+        },
+        "/api/v1/auth/refresh": {
+            "post": {
+                "description": "Обменивает refresh токен на новую пару токенов. Refresh токен одноразовый: повторное использование отзывает все токены сессии",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["auth"],
+                "summary": "Обновление токенов",
+                "parameters": [{
+                    "description": "Refresh токен",
+                    "name": "request",
+                    "in": "body",
+                    "required": true,
+                    "schema": {"$ref": "#/definitions/handlers.RefreshTokenRequest"}
+                }],
+                "responses": {
+                    "200": {
+                        "description": "Новая пара токенов",
+                        "schema": {"$ref": "#/definitions/handlers.RefreshTokenResponse"}
+                    },
+                    "400": {"description": "Невалидные данные", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Refresh токен невалиден, истёк или отозван", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                }
+            }
+        },
+        "/api/v1/auth/resend-verification": {
+            "post": {
+                "description": "Отправляет новое письмо подтверждения; ранее выданные токены перестают действовать. Ответ одинаков для любого email",
+                "consumes": ["application/json"],
+                "tags": ["auth"],
+                "summary": "Повторная отправка письма подтверждения",
+                "parameters": [{
+                    "description": "Email пользователя",
+                    "name": "request",
+                    "in": "body",
+                    "required": true,
+                    "schema": {"$ref": "#/definitions/handlers.ResendVerificationRequest"}
+                }],
+                "responses": {
+                    "202": {"description": "Письмо отправлено, если email зарегистрирован и не подтверждён"},
+                    "400": {"description": "Невалидные данные", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                }
+            }
+        },
         "/api/v1/auth/signin": {
             "post": {
-                "description": "Аутентификация пользователя и получение токена",
+                "description": "Аутентификация пользователя и получение токена. Если у пользователя включён второй фактор, вместо токенов возвращается mfa_token для POST /api/v1/auth/mfa/verify",
                 "consumes": ["application/json"],
                 "produces": ["application/json"],
                 "tags": ["auth"],
@@ -42,15 +221,38 @@ const docTemplate = `{
                         "schema": {"$ref": "#/definitions/handlers.SignInResponse"}
                     },
                     "400": {"description": "Невалидные данные", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
-                    "401": {"description": "Неверный пароль", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Неверный пароль; в режиме защиты от перебора аккаунтов - любой отказ во входе", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "403": {"description": "Email не подтверждён", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
                     "404": {"description": "Пользователь не найден", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "429": {"description": "Слишком много неудачных попыток, см. заголовок Retry-After", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
                     "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
                 }
             }
         },
+        "/api/v1/auth/signout": {
+            "post": {
+                "description": "Отзывает текущий access токен и, если передан, refresh токен вместе со всей сессией",
+                "consumes": ["application/json"],
+                "tags": ["auth"],
+                "summary": "Выход",
+                "parameters": [{
+                    "description": "Refresh токен сессии",
+                    "name": "request",
+                    "in": "body",
+                    "schema": {"$ref": "#/definitions/handlers.SignOutRequest"}
+                }],
+                "responses": {
+                    "204": {"description": "Сессия завершена"},
+                    "400": {"description": "Невалидные данные", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Отсутствует или невалидный токен", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                },
+                "security": [{"BearerAuth": []}]
+            }
+        },
         "/api/v1/auth/signup": {
             "post": {
-                "description": "Создаёт нового пользователя с email и паролем",
+                "description": "Создаёт нового пользователя с email и паролем. В режиме защиты от перебора аккаунтов ответ 202 одинаков для нового и занятого email: дальнейшие шаги приходят письмом",
                 "consumes": ["application/json"],
                 "produces": ["application/json"],
                 "tags": ["auth"],
@@ -67,6 +269,10 @@ const docTemplate = `{
                         "description": "Пользователь успешно создан",
                         "schema": {"$ref": "#/definitions/handlers.SignUpResponse"}
                     },
+                    "202": {
+                        "description": "Запрос принят, ожидается подтверждение email",
+                        "schema": {"$ref": "#/definitions/handlers.SignUpResponse"}
+                    },
                     "400": {"description": "Невалидные данные (email или пароль)", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
                     "409": {"description": "Пользователь с таким email уже существует", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
                     "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
@@ -98,6 +304,292 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/auth/verify-email": {
+            "post": {
+                "description": "Подтверждает email по одноразовому токену из письма, отправленного при регистрации или смене email. При смене email новый адрес применяется в этот момент",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["auth"],
+                "summary": "Подтверждение email",
+                "parameters": [{
+                    "description": "Токен из письма",
+                    "name": "request",
+                    "in": "body",
+                    "required": true,
+                    "schema": {"$ref": "#/definitions/handlers.VerifyEmailRequest"}
+                }],
+                "responses": {
+                    "200": {
+                        "description": "Email подтверждён",
+                        "schema": {"$ref": "#/definitions/handlers.VerifyEmailResponse"}
+                    },
+                    "400": {"description": "Токен невалиден, истёк или уже использован", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "409": {"description": "Новый email уже занят другим пользователем", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                }
+            }
+        },
+        "/api/v1/me": {
+            "delete": {
+                "description": "Удаляет аккаунт после проверки пароля. Вход сразу становится невозможен, данные во всех сервисах удаляются окончательно по истечении срока ожидания",
+                "consumes": ["application/json"],
+                "tags": ["account"],
+                "summary": "Удаление аккаунта",
+                "parameters": [{
+                    "description": "Пароль для подтверждения",
+                    "name": "request",
+                    "in": "body",
+                    "required": true,
+                    "schema": {"$ref": "#/definitions/handlers.DeleteAccountRequest"}
+                }],
+                "responses": {
+                    "204": {"description": "Аккаунт удалён"},
+                    "400": {"description": "Невалидные данные", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Отсутствует или невалидный токен", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "403": {"description": "Неверный пароль", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                },
+                "security": [{"BearerAuth": []}]
+            }
+        },
+        "/api/v1/me/email": {
+            "put": {
+                "description": "Отправляет письмо подтверждения на новый адрес после проверки пароля. Email меняется только после подтверждения по ссылке из письма, до этого вход выполняется по текущему адресу",
+                "consumes": ["application/json"],
+                "tags": ["account"],
+                "summary": "Смена email",
+                "parameters": [{
+                    "description": "Пароль и новый email",
+                    "name": "request",
+                    "in": "body",
+                    "required": true,
+                    "schema": {"$ref": "#/definitions/handlers.ChangeEmailRequest"}
+                }],
+                "responses": {
+                    "204": {"description": "Письмо подтверждения отправлено на новый адрес"},
+                    "400": {"description": "Невалидные данные", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Отсутствует или невалидный токен", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "403": {"description": "Неверный пароль", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "409": {"description": "Email уже занят", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                },
+                "security": [{"BearerAuth": []}]
+            }
+        },
+        "/api/v1/me/mfa/totp": {
+            "post": {
+                "description": "Выдаёт новый TOTP секрет и otpauth:// ссылку для QR-кода. Второй фактор включается после подтверждения кодом из приложения",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["account"],
+                "summary": "Подключение приложения-аутентификатора",
+                "parameters": [{
+                    "description": "Пароль для подтверждения",
+                    "name": "request",
+                    "in": "body",
+                    "required": true,
+                    "schema": {"$ref": "#/definitions/handlers.EnrollTOTPRequest"}
+                }],
+                "responses": {
+                    "200": {
+                        "description": "Секрет и ссылка для приложения",
+                        "schema": {"$ref": "#/definitions/handlers.EnrollTOTPResponse"}
+                    },
+                    "400": {"description": "Невалидные данные", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Отсутствует или невалидный токен", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "403": {"description": "Неверный пароль", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "409": {"description": "Второй фактор уже включён", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                },
+                "security": [{"BearerAuth": []}]
+            }
+        },
+        "/api/v1/me/mfa/totp/confirm": {
+            "post": {
+                "description": "Проверяет код из приложения-аутентификатора, включает второй фактор и возвращает коды восстановления. Коды показываются один раз",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["account"],
+                "summary": "Включение второго фактора",
+                "parameters": [{
+                    "description": "Код из приложения",
+                    "name": "request",
+                    "in": "body",
+                    "required": true,
+                    "schema": {"$ref": "#/definitions/handlers.ConfirmTOTPRequest"}
+                }],
+                "responses": {
+                    "200": {
+                        "description": "Второй фактор включён",
+                        "schema": {"$ref": "#/definitions/handlers.ConfirmTOTPResponse"}
+                    },
+                    "400": {"description": "Неверный код", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Отсутствует или невалидный токен", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "409": {"description": "Подключение не начато или второй фактор уже включён", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                },
+                "security": [{"BearerAuth": []}]
+            }
+        },
+        "/api/v1/me/password": {
+            "put": {
+                "description": "Меняет пароль после проверки текущего. Все выпущенные access токены и refresh токены остальных сессий отзываются; в ответе новый access токен. Сессия, чей refresh_token передан, продолжает обновляться",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["account"],
+                "summary": "Смена пароля",
+                "parameters": [{
+                    "description": "Текущий и новый пароль",
+                    "name": "request",
+                    "in": "body",
+                    "required": true,
+                    "schema": {"$ref": "#/definitions/handlers.ChangePasswordRequest"}
+                }],
+                "responses": {
+                    "200": {
+                        "description": "Пароль изменён",
+                        "schema": {"$ref": "#/definitions/handlers.ChangePasswordResponse"}
+                    },
+                    "400": {"description": "Невалидные данные", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Отсутствует или невалидный токен", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "403": {"description": "Неверный текущий пароль", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                },
+                "security": [{"BearerAuth": []}]
+            }
+        },
+        "/api/v1/notes": {
+            "get": {
+                "description": "Возвращает страницу заметок текущего пользователя, новые первыми",
+                "produces": ["application/json"],
+                "tags": ["notes"],
+                "summary": "Список заметок",
+                "parameters": [{
+                    "type": "integer",
+                    "default": 1,
+                    "description": "Номер страницы, начиная с 1",
+                    "name": "page",
+                    "in": "query"
+                },                 {
+                    "type": "integer",
+                    "default": 20,
+                    "description": "Размер страницы, не больше 100",
+                    "name": "page_size",
+                    "in": "query"
+                }],
+                "responses": {
+                    "200": {
+                        "description": "Страница заметок",
+                        "schema": {"$ref": "#/definitions/handlers.ListNotesResponse"}
+                    },
+                    "400": {"description": "Невалидные параметры пагинации", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Отсутствует или невалидный токен", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                },
+                "security": [{"BearerAuth": []}]
+            },
+            "post": {
+                "description": "Создаёт заметку текущего пользователя",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["notes"],
+                "summary": "Создание заметки",
+                "parameters": [{
+                    "description": "Заголовок и содержимое заметки",
+                    "name": "request",
+                    "in": "body",
+                    "required": true,
+                    "schema": {"$ref": "#/definitions/handlers.NoteRequest"}
+                }],
+                "responses": {
+                    "201": {
+                        "description": "Заметка создана",
+                        "schema": {"$ref": "#/definitions/handlers.NoteResponse"}
+                    },
+                    "400": {"description": "Невалидные данные", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Отсутствует или невалидный токен", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                },
+                "security": [{"BearerAuth": []}]
+            }
+        },
+        "/api/v1/notes/{id}": {
+            "get": {
+                "description": "Возвращает заметку текущего пользователя по ID",
+                "produces": ["application/json"],
+                "tags": ["notes"],
+                "summary": "Получение заметки",
+                "parameters": [{
+                    "type": "string",
+                    "description": "ID заметки",
+                    "name": "id",
+                    "in": "path",
+                    "required": true
+                }],
+                "responses": {
+                    "200": {
+                        "description": "Заметка",
+                        "schema": {"$ref": "#/definitions/handlers.NoteResponse"}
+                    },
+                    "400": {"description": "Невалидный ID", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Отсутствует или невалидный токен", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "404": {"description": "Заметка не найдена", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                },
+                "security": [{"BearerAuth": []}]
+            },
+            "put": {
+                "description": "Заменяет заголовок и содержимое заметки текущего пользователя",
+                "consumes": ["application/json"],
+                "produces": ["application/json"],
+                "tags": ["notes"],
+                "summary": "Обновление заметки",
+                "parameters": [{
+                    "type": "string",
+                    "description": "ID заметки",
+                    "name": "id",
+                    "in": "path",
+                    "required": true
+                },                 {
+                    "description": "Новые заголовок и содержимое",
+                    "name": "request",
+                    "in": "body",
+                    "required": true,
+                    "schema": {"$ref": "#/definitions/handlers.NoteRequest"}
+                }],
+                "responses": {
+                    "200": {
+                        "description": "Заметка обновлена",
+                        "schema": {"$ref": "#/definitions/handlers.NoteResponse"}
+                    },
+                    "400": {"description": "Невалидные данные", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Отсутствует или невалидный токен", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "404": {"description": "Заметка не найдена", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                },
+                "security": [{"BearerAuth": []}]
+            },
+            "delete": {
+                "description": "Удаляет заметку текущего пользователя",
+                "tags": ["notes"],
+                "summary": "Удаление заметки",
+                "parameters": [{
+                    "type": "string",
+                    "description": "ID заметки",
+                    "name": "id",
+                    "in": "path",
+                    "required": true
+                }],
+                "responses": {
+                    "204": {"description": "Заметка удалена"},
+                    "400": {"description": "Невалидный ID", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "401": {"description": "Отсутствует или невалидный токен", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "404": {"description": "Заметка не найдена", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}},
+                    "500": {"description": "Внутренняя ошибка сервера", "schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}
+                },
+                "security": [{"BearerAuth": []}]
+            }
+        },
         "/health": {
             "get": {
                 "description": "Проверка работоспособности сервиса",
@@ -111,10 +603,133 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "handlers.ChangeEmailRequest": {
+            "type": "object",
+            "properties": {
+                "new_email": {"type": "string", "example": "new@example.com"},
+                "password": {"type": "string", "example": "Password123!"}
+            }
+        },
+        "handlers.ChangePasswordRequest": {
+            "type": "object",
+            "properties": {
+                "current_password": {"type": "string", "example": "Password123!"},
+                "new_password": {"type": "string", "example": "NewPassword123!"},
+                "refresh_token": {"description": "RefreshToken refresh токен текущей сессии: она сохраняется, остальные завершаются", "type": "string", "example": "q1w2e3r4t5y6u7i8o9p0"}
+            }
+        },
+        "handlers.ChangePasswordResponse": {
+            "type": "object",
+            "properties": {
+                "token": {"description": "Token - новый access токен: прежние, включая токен запроса, отозваны", "type": "string", "example": "temporary_token"}
+            }
+        },
+        "handlers.ConfirmTOTPRequest": {
+            "type": "object",
+            "properties": {
+                "code": {"type": "string", "example": "123456"}
+            }
+        },
+        "handlers.ConfirmTOTPResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {"type": "array", "items": {"type": "string"}, "example": ["ABCD-EFGH-IJKL-MNOP"]}
+            }
+        },
+        "handlers.DeleteAccountRequest": {
+            "type": "object",
+            "properties": {
+                "password": {"type": "string", "example": "Password123!"}
+            }
+        },
+        "handlers.EnrollTOTPRequest": {
+            "type": "object",
+            "properties": {
+                "password": {"type": "string", "example": "Password123!"}
+            }
+        },
+        "handlers.EnrollTOTPResponse": {
+            "type": "object",
+            "properties": {
+                "otpauth_uri": {"type": "string", "example": "otpauth://totp/golang-project:user@example.com?secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP&issuer=golang-project"},
+                "secret": {"type": "string", "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"}
+            }
+        },
         "handlers.ErrorResponse": {
             "type": "object",
             "properties": {
-                "error": {"type": "string", "example": "invalid email format"}
+                "error": {"type": "string", "example": "invalid email format"},
+                "violations": {"description": "Violations - нарушения требований к полям запроса, например к паролю", "type": "array", "items": {"$ref": "#/definitions/handlers.FieldViolation"}}
+            }
+        },
+        "handlers.FieldViolation": {
+            "type": "object",
+            "properties": {
+                "code": {"type": "string", "example": "too_short"},
+                "field": {"type": "string", "example": "password"},
+                "message": {"type": "string", "example": "password too short (min 8 chars)"}
+            }
+        },
+        "handlers.GrantRoleRequest": {
+            "type": "object",
+            "properties": {
+                "role": {"type": "string", "example": "admin"}
+            }
+        },
+        "handlers.ListNotesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {"type": "array", "items": {"$ref": "#/definitions/handlers.NoteResponse"}},
+                "total": {"type": "integer", "example": 42}
+            }
+        },
+        "handlers.NoteRequest": {
+            "type": "object",
+            "properties": {
+                "content": {"type": "string", "example": "Молоко, хлеб, яйца"},
+                "title": {"type": "string", "example": "Список покупок"}
+            }
+        },
+        "handlers.NoteResponse": {
+            "type": "object",
+            "properties": {
+                "content": {"type": "string", "example": "Молоко, хлеб, яйца"},
+                "created_at": {"type": "integer", "example": 1700000000},
+                "id": {"type": "string", "example": "550e8400-e29b-41d4-a716-446655440000"},
+                "title": {"type": "string", "example": "Список покупок"},
+                "updated_at": {"type": "integer", "example": 1700000000}
+            }
+        },
+        "handlers.PasswordResetConfirmRequest": {
+            "type": "object",
+            "properties": {
+                "new_password": {"type": "string", "example": "NewPassword123!"},
+                "token": {"type": "string", "example": "p0o9i8u7y6t5r4e3w2q1"}
+            }
+        },
+        "handlers.PasswordResetRequest": {
+            "type": "object",
+            "properties": {
+                "email": {"type": "string", "example": "user@example.com"}
+            }
+        },
+        "handlers.RefreshTokenRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {"type": "string", "example": "q1w2e3r4t5y6u7i8o9p0"}
+            }
+        },
+        "handlers.RefreshTokenResponse": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {"type": "string", "example": "a9s8d7f6g5h4j3k2l1z0"},
+                "token": {"type": "string", "example": "temporary_token"}
+            }
+        },
+        "handlers.ResendVerificationRequest": {
+            "type": "object",
+            "properties": {
+                "email": {"type": "string", "example": "user@example.com"}
             }
         },
         "handlers.SignInRequest": {
@@ -127,28 +742,66 @@ const docTemplate = `{
         "handlers.SignInResponse": {
             "type": "object",
             "properties": {
+                "mfa_required": {"description": "MFARequired - нужен второй фактор: токены выдаст POST /api/v1/auth/mfa/verify по MFAToken", "type": "boolean", "example": false},
+                "mfa_token": {"type": "string", "example": "m1f2a3t4o5k6e7n8"},
+                "refresh_token": {"type": "string", "example": "q1w2e3r4t5y6u7i8o9p0"},
                 "token": {"type": "string", "example": "temporary_token"}
             }
         },
+        "handlers.SignOutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {"type": "string", "example": "q1w2e3r4t5y6u7i8o9p0"}
+            }
+        },
         "handlers.SignUpRequest": {
             "type": "object",
             "properties": {
                 "email": {"type": "string", "example": "user@example.com"},
-                "password": {"type": "string", "example": "Password123!"}
+                "password": {"type": "string", "example": "SecurePass123!"}
             }
         },
         "handlers.SignUpResponse": {
             "type": "object",
             "properties": {
+                "user_id": {"type": "string", "example": "550e8400-e29b-41d4-a716-446655440000"},
+                "verification_pending": {"description": "VerificationPending - регистрация завершится после перехода по ссылке из письма", "type": "boolean", "example": false}
+            }
+        },
+        "handlers.UserRolesResponse": {
+            "type": "object",
+            "properties": {
+                "roles": {"type": "array", "items": {"type": "string"}, "example": ["admin"]},
                 "user_id": {"type": "string", "example": "550e8400-e29b-41d4-a716-446655440000"}
             }
         },
         "handlers.ValidateTokenResponse": {
             "type": "object",
             "properties": {
+                "roles": {"type": "array", "items": {"type": "string"}, "example": ["admin"]},
+                "scopes": {"type": "array", "items": {"type": "string"}, "example": ["notes:read"]},
                 "user_id": {"type": "string", "example": "123"},
                 "valid": {"type": "boolean", "example": true}
             }
+        },
+        "handlers.VerifyEmailRequest": {
+            "type": "object",
+            "properties": {
+                "token": {"type": "string", "example": "z9x8c7v6b5n4m3l2k1j0"}
+            }
+        },
+        "handlers.VerifyEmailResponse": {
+            "type": "object",
+            "properties": {
+                "user_id": {"type": "string", "example": "550e8400-e29b-41d4-a716-446655440000"}
+            }
+        },
+        "handlers.VerifyMFARequest": {
+            "type": "object",
+            "properties": {
+                "code": {"description": "Code - 6 цифр из приложения-аутентификатора или код восстановления", "type": "string", "example": "123456"},
+                "mfa_token": {"type": "string", "example": "m1f2a3t4o5k6e7n8"}
+            }
         }
     },
     "securityDefinitions": {
@@ -168,7 +821,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/",
 	Schemes:          []string{},
 	Title:            "Golang Microservices API",
-	Description:      "REST API Gateway для микросервисной архитектуры на Go с gRPC\n\nЭтот API предоставляет endpoints для аутентификации пользователей. Backend построен на микросервисной архитектуре с использованием gRPC для межсервисного взаимодействия.",
+	Description:      "REST API Gateway для микросервисной архитектуры на Go с gRPC\n\nЭтот API предоставляет endpoints для аутентификации пользователей и работы с заметками. Backend построен на микросервисной архитектуре с использованием gRPC для межсервисного взаимодействия.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
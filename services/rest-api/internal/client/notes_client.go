@@ -0,0 +1,39 @@
+package client
+
+import (
+	"fmt"
+
+	notesv1 "golang-project/api/proto/gen/go/notes/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// NotesClient представляет gRPC клиент для notes-service
+type NotesClient struct {
+	conn   *grpc.ClientConn
+	Client notesv1.NotesServiceClient
+}
+
+// NewNotesClient создаёт новый gRPC клиент для notes-service
+func NewNotesClient(addr string) (*NotesClient, error) {
+	conn, err := grpc.NewClient(
+		addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to notes service: %w", err)
+	}
+
+	return &NotesClient{
+		conn:   conn,
+		Client: notesv1.NewNotesServiceClient(conn),
+	}, nil
+}
+
+// Close закрывает соединение с gRPC сервером
+func (c *NotesClient) Close() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	authv1 "golang-project/api/proto/gen/go/auth/v1"
+	"golang-project/services/rest-api/internal/client"
+	"golang-project/services/rest-api/internal/middleware"
+)
+
+// AccountHandler обрабатывает HTTP запросы пользователя к собственному аккаунту.
+// Маршруты закрываются middleware.Auth.
+type AccountHandler struct {
+	authClient *client.AuthClient
+}
+
+// NewAccountHandler создаёт новый обработчик запросов к аккаунту
+func NewAccountHandler(authClient *client.AuthClient) *AccountHandler {
+	return &AccountHandler{
+		authClient: authClient,
+	}
+}
+
+// ChangePasswordRequest - тело запроса для смены пароля
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" example:"Password123!"`
+	NewPassword     string `json:"new_password" example:"NewPassword123!"`
+	// RefreshToken refresh токен текущей сессии: она сохраняется, остальные завершаются
+	RefreshToken string `json:"refresh_token,omitempty" example:"q1w2e3r4t5y6u7i8o9p0"`
+}
+
+// ChangeEmailRequest - тело запроса для смены email
+type ChangeEmailRequest struct {
+	Password string `json:"password" example:"Password123!"`
+	NewEmail string `json:"new_email" example:"new@example.com"`
+}
+
+// EnrollTOTPRequest - тело запроса для подключения приложения-аутентификатора
+type EnrollTOTPRequest struct {
+	Password string `json:"password" example:"Password123!"`
+}
+
+// EnrollTOTPResponse - тело ответа для подключения приложения-аутентификатора
+type EnrollTOTPResponse struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	OtpauthURI string `json:"otpauth_uri" example:"otpauth://totp/golang-project:user@example.com?secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP&issuer=golang-project"`
+}
+
+// ConfirmTOTPRequest - тело запроса для включения второго фактора
+type ConfirmTOTPRequest struct {
+	Code string `json:"code" example:"123456"`
+}
+
+// ConfirmTOTPResponse - тело ответа для включения второго фактора
+type ConfirmTOTPResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"ABCD-EFGH-IJKL-MNOP"`
+}
+
+// ChangePasswordResponse - ответ на смену пароля
+type ChangePasswordResponse struct {
+	// Token - новый access токен: прежние, включая токен запроса, отозваны
+	Token string `json:"token" example:"temporary_token"`
+}
+
+// DeleteAccountRequest - тело запроса для удаления аккаунта
+type DeleteAccountRequest struct {
+	Password string `json:"password" example:"Password123!"`
+}
+
+// ChangePassword обрабатывает PUT /api/v1/me/password
+// @Summary      Смена пароля
+// @Description  Меняет пароль после проверки текущего. Все выпущенные access токены и refresh токены остальных сессий отзываются; в ответе новый access токен. Сессия, чей refresh_token передан, продолжает обновляться
+// @Tags         account
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body ChangePasswordRequest true "Текущий и новый пароль"
+// @Success      200 {object} ChangePasswordResponse "Пароль изменён"
+// @Failure      400 {object} ErrorResponse "Невалидные данные"
+// @Failure      401 {object} ErrorResponse "Отсутствует или невалидный токен"
+// @Failure      403 {object} ErrorResponse "Неверный текущий пароль"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/me/password [put]
+func (h *AccountHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	accessToken, _ := middleware.BearerToken(r)
+
+	var req ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("failed to decode request", "error", err)
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resp, err := h.authClient.Client.ChangePassword(r.Context(), &authv1.ChangePasswordRequest{
+		AccessToken:     accessToken,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+		RefreshToken:    req.RefreshToken,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ChangePasswordResponse{
+		Token: resp.AccessToken,
+	})
+}
+
+// ChangeEmail обрабатывает PUT /api/v1/me/email
+// @Summary      Смена email
+// @Description  Отправляет письмо подтверждения на новый адрес после проверки пароля. Email меняется только после подтверждения по ссылке из письма, до этого вход выполняется по текущему адресу
+// @Tags         account
+// @Accept       json
+// @Security     BearerAuth
+// @Param        request body ChangeEmailRequest true "Пароль и новый email"
+// @Success      204 "Письмо подтверждения отправлено на новый адрес"
+// @Failure      400 {object} ErrorResponse "Невалидные данные"
+// @Failure      401 {object} ErrorResponse "Отсутствует или невалидный токен"
+// @Failure      403 {object} ErrorResponse "Неверный пароль"
+// @Failure      409 {object} ErrorResponse "Email уже занят"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/me/email [put]
+func (h *AccountHandler) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	accessToken, _ := middleware.BearerToken(r)
+
+	var req ChangeEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("failed to decode request", "error", err)
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	_, err := h.authClient.Client.ChangeEmail(r.Context(), &authv1.ChangeEmailRequest{
+		AccessToken: accessToken,
+		Password:    req.Password,
+		NewEmail:    req.NewEmail,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteAccount обрабатывает DELETE /api/v1/me
+// @Summary      Удаление аккаунта
+// @Description  Удаляет аккаунт после проверки пароля. Вход сразу становится невозможен, данные во всех сервисах удаляются окончательно по истечении срока ожидания
+// @Tags         account
+// @Accept       json
+// @Security     BearerAuth
+// @Param        request body DeleteAccountRequest true "Пароль для подтверждения"
+// @Success      204 "Аккаунт удалён"
+// @Failure      400 {object} ErrorResponse "Невалидные данные"
+// @Failure      401 {object} ErrorResponse "Отсутствует или невалидный токен"
+// @Failure      403 {object} ErrorResponse "Неверный пароль"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/me [delete]
+func (h *AccountHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	accessToken, _ := middleware.BearerToken(r)
+
+	var req DeleteAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("failed to decode request", "error", err)
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	_, err := h.authClient.Client.DeleteUser(r.Context(), &authv1.DeleteUserRequest{
+		AccessToken: accessToken,
+		Password:    req.Password,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EnrollTOTP обрабатывает POST /api/v1/me/mfa/totp
+// @Summary      Подключение приложения-аутентификатора
+// @Description  Выдаёт новый TOTP секрет и otpauth:// ссылку для QR-кода. Второй фактор включается после подтверждения кодом из приложения
+// @Tags         account
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body EnrollTOTPRequest true "Пароль для подтверждения"
+// @Success      200 {object} EnrollTOTPResponse "Секрет и ссылка для приложения"
+// @Failure      400 {object} ErrorResponse "Невалидные данные"
+// @Failure      401 {object} ErrorResponse "Отсутствует или невалидный токен"
+// @Failure      403 {object} ErrorResponse "Неверный пароль"
+// @Failure      409 {object} ErrorResponse "Второй фактор уже включён"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/me/mfa/totp [post]
+func (h *AccountHandler) EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	accessToken, _ := middleware.BearerToken(r)
+
+	var req EnrollTOTPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("failed to decode request", "error", err)
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resp, err := h.authClient.Client.EnrollTOTP(r.Context(), &authv1.EnrollTOTPRequest{
+		AccessToken: accessToken,
+		Password:    req.Password,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, EnrollTOTPResponse{
+		Secret:     resp.Secret,
+		OtpauthURI: resp.OtpauthUri,
+	})
+}
+
+// ConfirmTOTP обрабатывает POST /api/v1/me/mfa/totp/confirm
+// @Summary      Включение второго фактора
+// @Description  Проверяет код из приложения-аутентификатора, включает второй фактор и возвращает коды восстановления. Коды показываются один раз
+// @Tags         account
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body ConfirmTOTPRequest true "Код из приложения"
+// @Success      200 {object} ConfirmTOTPResponse "Второй фактор включён"
+// @Failure      400 {object} ErrorResponse "Неверный код"
+// @Failure      401 {object} ErrorResponse "Отсутствует или невалидный токен"
+// @Failure      409 {object} ErrorResponse "Подключение не начато или второй фактор уже включён"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/me/mfa/totp/confirm [post]
+func (h *AccountHandler) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	accessToken, _ := middleware.BearerToken(r)
+
+	var req ConfirmTOTPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("failed to decode request", "error", err)
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resp, err := h.authClient.Client.ConfirmTOTP(r.Context(), &authv1.ConfirmTOTPRequest{
+		AccessToken: accessToken,
+		Code:        req.Code,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ConfirmTOTPResponse{
+		RecoveryCodes: resp.RecoveryCodes,
+	})
+}
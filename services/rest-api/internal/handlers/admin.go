@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	authv1 "golang-project/api/proto/gen/go/auth/v1"
+	"golang-project/services/rest-api/internal/client"
+	"golang-project/services/rest-api/internal/middleware"
+)
+
+// AdminHandler обрабатывает административные HTTP запросы.
+// Маршруты закрываются middleware.Auth и middleware.RequireRole("admin");
+// auth-service дополнительно проверяет роль по БД.
+type AdminHandler struct {
+	authClient *client.AuthClient
+}
+
+// NewAdminHandler создаёт новый обработчик административных запросов
+func NewAdminHandler(authClient *client.AuthClient) *AdminHandler {
+	return &AdminHandler{
+		authClient: authClient,
+	}
+}
+
+// GrantRoleRequest - тело запроса для выдачи роли
+type GrantRoleRequest struct {
+	Role string `json:"role" example:"admin"`
+}
+
+// UserRolesResponse - роли пользователя после изменения
+type UserRolesResponse struct {
+	UserID string   `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Roles  []string `json:"roles" example:"admin"`
+}
+
+// GrantRole обрабатывает POST /api/v1/admin/users/{id}/roles
+// @Summary      Выдача роли
+// @Description  Выдаёт пользователю роль. Роль попадёт в токен пользователя после его обновления
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "ID пользователя"
+// @Param        request body GrantRoleRequest true "Роль"
+// @Success      200 {object} UserRolesResponse "Текущие роли пользователя"
+// @Failure      400 {object} ErrorResponse "Невалидные данные"
+// @Failure      401 {object} ErrorResponse "Отсутствует или невалидный токен"
+// @Failure      403 {object} ErrorResponse "Требуется роль admin"
+// @Failure      404 {object} ErrorResponse "Пользователь или роль не найдены"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/admin/users/{id}/roles [post]
+func (h *AdminHandler) GrantRole(w http.ResponseWriter, r *http.Request) {
+	accessToken, _ := middleware.BearerToken(r)
+
+	var req GrantRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("failed to decode request", "error", err)
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	userID := chi.URLParam(r, "id")
+	resp, err := h.authClient.Client.GrantRole(r.Context(), &authv1.GrantRoleRequest{
+		AccessToken: accessToken,
+		UserId:      userID,
+		Role:        req.Role,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, UserRolesResponse{
+		UserID: userID,
+		Roles:  resp.Roles,
+	})
+}
+
+// RevokeRole обрабатывает DELETE /api/v1/admin/users/{id}/roles/{role}
+// @Summary      Отзыв роли
+// @Description  Отзывает роль у пользователя. Собственную роль admin отозвать нельзя
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "ID пользователя"
+// @Param        role path string true "Роль"
+// @Success      200 {object} UserRolesResponse "Текущие роли пользователя"
+// @Failure      400 {object} ErrorResponse "Невалидные данные"
+// @Failure      401 {object} ErrorResponse "Отсутствует или невалидный токен"
+// @Failure      403 {object} ErrorResponse "Требуется роль admin"
+// @Failure      404 {object} ErrorResponse "Пользователь не найден"
+// @Failure      409 {object} ErrorResponse "Попытка отозвать собственную роль admin"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/admin/users/{id}/roles/{role} [delete]
+func (h *AdminHandler) RevokeRole(w http.ResponseWriter, r *http.Request) {
+	accessToken, _ := middleware.BearerToken(r)
+
+	userID := chi.URLParam(r, "id")
+	resp, err := h.authClient.Client.RevokeRole(r.Context(), &authv1.RevokeRoleRequest{
+		AccessToken: accessToken,
+		UserId:      userID,
+		Role:        chi.URLParam(r, "role"),
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, UserRolesResponse{
+		UserID: userID,
+		Roles:  resp.Roles,
+	})
+}
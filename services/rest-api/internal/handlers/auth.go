@@ -3,36 +3,47 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 
 	authv1 "golang-project/api/proto/gen/go/auth/v1"
+	"golang-project/pkg/clientip"
 	"golang-project/services/rest-api/internal/client"
+	"golang-project/services/rest-api/internal/middleware"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // AuthHandler обрабатывает HTTP запросы для аутентификации
 type AuthHandler struct {
-	authClient *client.AuthClient
+	authClient    *client.AuthClient
+	clientIPToken string
 }
 
-// NewAuthHandler создаёт новый обработчик для аутентификации
-func NewAuthHandler(authClient *client.AuthClient) *AuthHandler {
+// NewAuthHandler создаёт новый обработчик для аутентификации.
+// clientIPToken подтверждает auth-service IP клиента; пустая строка - IP не передаётся.
+func NewAuthHandler(authClient *client.AuthClient, clientIPToken string) *AuthHandler {
 	return &AuthHandler{
-		authClient: authClient,
+		authClient:    authClient,
+		clientIPToken: clientIPToken,
 	}
 }
 
 // SignUpRequest - тело запроса для регистрации
 type SignUpRequest struct {
 	Email    string `json:"email" example:"user@example.com"`
-	Password string `json:"password" example:"Password123!"`
+	Password string `json:"password" example:"SecurePass123!"`
 }
 
 // SignUpResponse - тело ответа для регистрации
 type SignUpResponse struct {
-	UserID string `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	UserID string `json:"user_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// VerificationPending - регистрация завершится после перехода по ссылке из письма
+	VerificationPending bool `json:"verification_pending,omitempty" example:"false"`
 }
 
 // SignInRequest - тело запроса для входа
@@ -43,28 +54,93 @@ type SignInRequest struct {
 
 // SignInResponse - тело ответа для входа
 type SignInResponse struct {
-	Token string `json:"token" example:"temporary_token"`
+	Token        string `json:"token,omitempty" example:"temporary_token"`
+	RefreshToken string `json:"refresh_token,omitempty" example:"q1w2e3r4t5y6u7i8o9p0"`
+	// MFARequired - нужен второй фактор: токены выдаст POST /api/v1/auth/mfa/verify по MFAToken
+	MFARequired bool   `json:"mfa_required,omitempty" example:"false"`
+	MFAToken    string `json:"mfa_token,omitempty" example:"m1f2a3t4o5k6e7n8"`
+}
+
+// VerifyMFARequest - тело запроса для второго шага входа
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfa_token" example:"m1f2a3t4o5k6e7n8"`
+	// Code - 6 цифр из приложения-аутентификатора или код восстановления
+	Code string `json:"code" example:"123456"`
+}
+
+// RefreshTokenRequest - тело запроса для обновления токенов
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" example:"q1w2e3r4t5y6u7i8o9p0"`
+}
+
+// RefreshTokenResponse - тело ответа для обновления токенов
+type RefreshTokenResponse struct {
+	Token        string `json:"token" example:"temporary_token"`
+	RefreshToken string `json:"refresh_token" example:"a9s8d7f6g5h4j3k2l1z0"`
+}
+
+// SignOutRequest - тело запроса для выхода
+type SignOutRequest struct {
+	RefreshToken string `json:"refresh_token" example:"q1w2e3r4t5y6u7i8o9p0"`
+}
+
+// VerifyEmailRequest - тело запроса для подтверждения email
+type VerifyEmailRequest struct {
+	Token string `json:"token" example:"z9x8c7v6b5n4m3l2k1j0"`
+}
+
+// VerifyEmailResponse - тело ответа для подтверждения email
+type VerifyEmailResponse struct {
+	UserID string `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// ResendVerificationRequest - тело запроса для повторной отправки письма подтверждения
+type ResendVerificationRequest struct {
+	Email string `json:"email" example:"user@example.com"`
+}
+
+// PasswordResetRequest - тело запроса для сброса пароля
+type PasswordResetRequest struct {
+	Email string `json:"email" example:"user@example.com"`
+}
+
+// PasswordResetConfirmRequest - тело запроса для установки нового пароля
+type PasswordResetConfirmRequest struct {
+	Token       string `json:"token" example:"p0o9i8u7y6t5r4e3w2q1"`
+	NewPassword string `json:"new_password" example:"NewPassword123!"`
 }
 
 // ValidateTokenResponse - тело ответа для валидации токена
 type ValidateTokenResponse struct {
-	UserID string `json:"user_id" example:"123"`
-	Valid  bool   `json:"valid" example:"true"`
+	UserID string   `json:"user_id" example:"123"`
+	Valid  bool     `json:"valid" example:"true"`
+	Roles  []string `json:"roles,omitempty" example:"admin"`
+	Scopes []string `json:"scopes,omitempty" example:"notes:read"`
 }
 
 // ErrorResponse - стандартный ответ об ошибке
 type ErrorResponse struct {
 	Error string `json:"error" example:"invalid email format"`
+	// Violations - нарушения требований к полям запроса, например к паролю
+	Violations []FieldViolation `json:"violations,omitempty"`
+}
+
+// FieldViolation - нарушение требования к полю запроса
+type FieldViolation struct {
+	Field   string `json:"field" example:"password"`
+	Code    string `json:"code" example:"too_short"`
+	Message string `json:"message" example:"password too short (min 8 chars)"`
 }
 
 // SignUp обрабатывает POST /api/v1/auth/signup
 // @Summary      Регистрация нового пользователя
-// @Description  Создаёт нового пользователя с email и паролем
+// @Description  Создаёт нового пользователя с email и паролем. В режиме защиты от перебора аккаунтов ответ 202 одинаков для нового и занятого email: дальнейшие шаги приходят письмом
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        request body SignUpRequest true "Данные для регистрации"
 // @Success      201 {object} SignUpResponse "Пользователь успешно создан"
+// @Success      202 {object} SignUpResponse "Запрос принят, ожидается подтверждение email"
 // @Failure      400 {object} ErrorResponse "Невалидные данные (email или пароль)"
 // @Failure      409 {object} ErrorResponse "Пользователь с таким email уже существует"
 // @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
@@ -87,22 +163,29 @@ func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respondJSON(w, http.StatusCreated, SignUpResponse{
-		UserID: resp.UserId,
+	code := http.StatusCreated
+	if resp.VerificationPending {
+		code = http.StatusAccepted
+	}
+	respondJSON(w, code, SignUpResponse{
+		UserID:              resp.UserId,
+		VerificationPending: resp.VerificationPending,
 	})
 }
 
 // SignIn обрабатывает POST /api/v1/auth/signin
 // @Summary      Вход пользователя
-// @Description  Аутентификация пользователя и получение токена
+// @Description  Аутентификация пользователя и получение токена. Если у пользователя включён второй фактор, вместо токенов возвращается mfa_token для POST /api/v1/auth/mfa/verify
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        request body SignInRequest true "Данные для входа"
 // @Success      200 {object} SignInResponse "Успешный вход, токен выдан"
 // @Failure      400 {object} ErrorResponse "Невалидные данные"
-// @Failure      401 {object} ErrorResponse "Неверный пароль"
+// @Failure      401 {object} ErrorResponse "Неверный пароль; в режиме защиты от перебора аккаунтов - любой отказ во входе"
+// @Failure      403 {object} ErrorResponse "Email не подтверждён"
 // @Failure      404 {object} ErrorResponse "Пользователь не найден"
+// @Failure      429 {object} ErrorResponse "Слишком много неудачных попыток, см. заголовок Retry-After"
 // @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
 // @Router       /api/v1/auth/signin [post]
 func (h *AuthHandler) SignIn(w http.ResponseWriter, r *http.Request) {
@@ -113,7 +196,9 @@ func (h *AuthHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.authClient.Client.SignIn(context.Background(), &authv1.SignInRequest{
+	// IP клиента (после middleware.RealIP с доверенными прокси) нужен auth-service для ограничения попыток входа
+	ctx := clientip.NewOutgoingContext(r.Context(), r.RemoteAddr, h.clientIPToken)
+	resp, err := h.authClient.Client.SignIn(ctx, &authv1.SignInRequest{
 		Email:    req.Email,
 		Password: req.Password,
 	})
@@ -124,10 +209,252 @@ func (h *AuthHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 	}
 
 	respondJSON(w, http.StatusOK, SignInResponse{
-		Token: resp.AccessToken,
+		Token:        resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		MFARequired:  resp.MfaRequired,
+		MFAToken:     resp.MfaToken,
+	})
+}
+
+// VerifyMFA обрабатывает POST /api/v1/auth/mfa/verify
+// @Summary      Второй шаг входа
+// @Description  Обменивает mfa_token из ответа на вход и код из приложения-аутентификатора или код восстановления на пару токенов. После пяти неверных кодов mfa_token гасится
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body VerifyMFARequest true "Токен второго шага и код"
+// @Success      200 {object} SignInResponse "Успешный вход, токен выдан"
+// @Failure      400 {object} ErrorResponse "Невалидные данные"
+// @Failure      401 {object} ErrorResponse "Неверный код или mfa_token невалиден, истёк или погашен"
+// @Failure      429 {object} ErrorResponse "Слишком много неудачных попыток, см. заголовок Retry-After"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(w http.ResponseWriter, r *http.Request) {
+	var req VerifyMFARequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("failed to decode request", "error", err)
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Неверные коды учитываются ограничителем попыток входа, как и на первом шаге
+	ctx := clientip.NewOutgoingContext(r.Context(), r.RemoteAddr, h.clientIPToken)
+	resp, err := h.authClient.Client.VerifyMFA(ctx, &authv1.VerifyMFARequest{
+		MfaToken: req.MFAToken,
+		Code:     req.Code,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, SignInResponse{
+		Token:        resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
 	})
 }
 
+// RefreshToken обрабатывает POST /api/v1/auth/refresh
+// @Summary      Обновление токенов
+// @Description  Обменивает refresh токен на новую пару токенов. Refresh токен одноразовый: повторное использование отзывает все токены сессии
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body RefreshTokenRequest true "Refresh токен"
+// @Success      200 {object} RefreshTokenResponse "Новая пара токенов"
+// @Failure      400 {object} ErrorResponse "Невалидные данные"
+// @Failure      401 {object} ErrorResponse "Refresh токен невалиден, истёк или отозван"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/auth/refresh [post]
+func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	var req RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("failed to decode request", "error", err)
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resp, err := h.authClient.Client.RefreshToken(context.Background(), &authv1.RefreshTokenRequest{
+		RefreshToken: req.RefreshToken,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, RefreshTokenResponse{
+		Token:        resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+	})
+}
+
+// SignOut обрабатывает POST /api/v1/auth/signout
+// @Summary      Выход
+// @Description  Отзывает текущий access токен и, если передан, refresh токен вместе со всей сессией
+// @Tags         auth
+// @Accept       json
+// @Security     BearerAuth
+// @Param        request body SignOutRequest false "Refresh токен сессии"
+// @Success      204 "Сессия завершена"
+// @Failure      400 {object} ErrorResponse "Невалидные данные"
+// @Failure      401 {object} ErrorResponse "Отсутствует или невалидный токен"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/auth/signout [post]
+func (h *AuthHandler) SignOut(w http.ResponseWriter, r *http.Request) {
+	accessToken, ok := middleware.BearerToken(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "missing authorization header")
+		return
+	}
+
+	// Тело необязательно: без refresh токена отзывается только access токен
+	var req SignOutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		slog.Error("failed to decode request", "error", err)
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	_, err := h.authClient.Client.SignOut(r.Context(), &authv1.SignOutRequest{
+		AccessToken:  accessToken,
+		RefreshToken: req.RefreshToken,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// VerifyEmail обрабатывает POST /api/v1/auth/verify-email
+// @Summary      Подтверждение email
+// @Description  Подтверждает email по одноразовому токену из письма, отправленного при регистрации или смене email. При смене email новый адрес применяется в этот момент
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body VerifyEmailRequest true "Токен из письма"
+// @Success      200 {object} VerifyEmailResponse "Email подтверждён"
+// @Failure      400 {object} ErrorResponse "Токен невалиден, истёк или уже использован"
+// @Failure      409 {object} ErrorResponse "Новый email уже занят другим пользователем"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("failed to decode request", "error", err)
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resp, err := h.authClient.Client.VerifyEmail(r.Context(), &authv1.VerifyEmailRequest{
+		Token: req.Token,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, VerifyEmailResponse{
+		UserID: resp.UserId,
+	})
+}
+
+// ResendVerification обрабатывает POST /api/v1/auth/resend-verification
+// @Summary      Повторная отправка письма подтверждения
+// @Description  Отправляет новое письмо подтверждения; ранее выданные токены перестают действовать. Ответ одинаков для любого email
+// @Tags         auth
+// @Accept       json
+// @Param        request body ResendVerificationRequest true "Email пользователя"
+// @Success      202 "Письмо отправлено, если email зарегистрирован и не подтверждён"
+// @Failure      400 {object} ErrorResponse "Невалидные данные"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/auth/resend-verification [post]
+func (h *AuthHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	var req ResendVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("failed to decode request", "error", err)
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	_, err := h.authClient.Client.ResendVerification(r.Context(), &authv1.ResendVerificationRequest{
+		Email: req.Email,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// RequestPasswordReset обрабатывает POST /api/v1/auth/password-reset
+// @Summary      Запрос сброса пароля
+// @Description  Отправляет на email письмо с одноразовым токеном сброса пароля. Ответ одинаков для любого email
+// @Tags         auth
+// @Accept       json
+// @Param        request body PasswordResetRequest true "Email пользователя"
+// @Success      202 "Письмо отправлено, если email зарегистрирован"
+// @Failure      400 {object} ErrorResponse "Невалидные данные"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/auth/password-reset [post]
+func (h *AuthHandler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req PasswordResetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("failed to decode request", "error", err)
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	_, err := h.authClient.Client.RequestPasswordReset(r.Context(), &authv1.RequestPasswordResetRequest{
+		Email: req.Email,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ResetPassword обрабатывает POST /api/v1/auth/password-reset/confirm
+// @Summary      Установка нового пароля
+// @Description  Устанавливает новый пароль по токену из письма. Все сессии пользователя завершаются
+// @Tags         auth
+// @Accept       json
+// @Param        request body PasswordResetConfirmRequest true "Токен из письма и новый пароль"
+// @Success      204 "Пароль изменён"
+// @Failure      400 {object} ErrorResponse "Невалидный пароль или токен невалиден, истёк или уже использован"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/auth/password-reset/confirm [post]
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req PasswordResetConfirmRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("failed to decode request", "error", err)
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	_, err := h.authClient.Client.ResetPassword(r.Context(), &authv1.ResetPasswordRequest{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // ValidateToken обрабатывает GET /api/v1/auth/validate
 // @Summary      Проверка токена
 // @Description  Валидация JWT токена и получение информации о пользователе
@@ -163,6 +490,8 @@ func (h *AuthHandler) ValidateToken(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, ValidateTokenResponse{
 		UserID: resp.UserId,
 		Valid:  resp.Valid,
+		Roles:  resp.Roles,
+		Scopes: resp.Scopes,
 	})
 }
 
@@ -191,6 +520,23 @@ func handleGRPCError(w http.ResponseWriter, err error) {
 	var httpStatus int
 	switch st.Code() {
 	case codes.InvalidArgument:
+		// Нарушения политики паролей передаются списком, чтобы клиент показал их все
+		var violations []FieldViolation
+		for _, detail := range st.Details() {
+			if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+				for _, v := range badRequest.FieldViolations {
+					violations = append(violations, FieldViolation{
+						Field:   v.Field,
+						Code:    v.Reason,
+						Message: v.Description,
+					})
+				}
+			}
+		}
+		if len(violations) > 0 {
+			respondJSON(w, http.StatusBadRequest, ErrorResponse{Error: st.Message(), Violations: violations})
+			return
+		}
 		httpStatus = http.StatusBadRequest
 	case codes.NotFound:
 		httpStatus = http.StatusNotFound
@@ -200,6 +546,16 @@ func handleGRPCError(w http.ResponseWriter, err error) {
 		httpStatus = http.StatusUnauthorized
 	case codes.PermissionDenied:
 		httpStatus = http.StatusForbidden
+	case codes.FailedPrecondition:
+		httpStatus = http.StatusConflict
+	case codes.ResourceExhausted:
+		httpStatus = http.StatusTooManyRequests
+		for _, detail := range st.Details() {
+			if info, ok := detail.(*errdetails.RetryInfo); ok && info.RetryDelay != nil {
+				seconds := int64(math.Ceil(info.RetryDelay.AsDuration().Seconds()))
+				w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+			}
+		}
 	default:
 		httpStatus = http.StatusInternalServerError
 	}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	authv1 "golang-project/api/proto/gen/go/auth/v1"
+	"golang-project/pkg/clientip"
+	"golang-project/services/rest-api/internal/client"
+	"golang-project/services/rest-api/internal/middleware"
+)
+
+// stubAuthClient запоминает metadata запросов SignIn; остальные методы не реализованы
+type stubAuthClient struct {
+	authv1.AuthServiceClient
+	signInMD []metadata.MD
+}
+
+func (c *stubAuthClient) SignIn(ctx context.Context, in *authv1.SignInRequest, opts ...grpc.CallOption) (*authv1.SignInResponse, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	c.signInMD = append(c.signInMD, md)
+	return &authv1.SignInResponse{AccessToken: "access", RefreshToken: "refresh"}, nil
+}
+
+func TestAuthHandler_SignIn_ClientIP(t *testing.T) {
+	const token = "gateway-secret"
+	trusted, err := middleware.ParseTrustedProxies("10.0.0.0/8")
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		// spoofed значения X-Real-IP и X-Forwarded-For в последовательных запросах
+		spoofed []string
+		want    string
+	}{
+		{
+			name:       "direct client rotates headers",
+			remoteAddr: "203.0.113.5:41000",
+			spoofed:    []string{"198.51.100.1", "198.51.100.2", "192.0.2.77"},
+			want:       "203.0.113.5",
+		},
+		{
+			name:       "trusted proxy forwards client",
+			remoteAddr: "10.1.2.3:41000",
+			spoofed:    []string{"203.0.113.5"},
+			want:       "203.0.113.5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubAuthClient{}
+			h := NewAuthHandler(&client.AuthClient{Client: stub}, token)
+			handler := middleware.RealIP(trusted)(http.HandlerFunc(h.SignIn))
+
+			for _, spoofed := range tt.spoofed {
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/signin", strings.NewReader(`{"email":"user@example.com","password":"secret"}`))
+				req.RemoteAddr = tt.remoteAddr
+				req.Header.Set("X-Real-IP", spoofed)
+				req.Header.Set("X-Forwarded-For", spoofed)
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+				if rec.Code != http.StatusOK {
+					t.Fatalf("SignIn() status = %d, body %s", rec.Code, rec.Body)
+				}
+			}
+
+			// Ограничитель попыток входа в auth-service считает по этому IP
+			for i, md := range stub.signInMD {
+				if got := md.Get(clientip.MetadataKey); len(got) != 1 || got[0] != tt.want {
+					t.Errorf("request %d %s = %v, want %s", i, clientip.MetadataKey, got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	notesv1 "golang-project/api/proto/gen/go/notes/v1"
+	"golang-project/services/rest-api/internal/client"
+	"golang-project/services/rest-api/internal/middleware"
+)
+
+// NotesHandler обрабатывает HTTP запросы для заметок.
+// Все маршруты должны быть закрыты middleware.Auth: владелец заметки
+// берётся из токена, а не из тела запроса.
+type NotesHandler struct {
+	notesClient *client.NotesClient
+}
+
+// NewNotesHandler создаёт новый обработчик для заметок
+func NewNotesHandler(notesClient *client.NotesClient) *NotesHandler {
+	return &NotesHandler{
+		notesClient: notesClient,
+	}
+}
+
+// NoteRequest - тело запроса для создания и обновления заметки
+type NoteRequest struct {
+	Title   string `json:"title" example:"Список покупок"`
+	Content string `json:"content" example:"Молоко, хлеб, яйца"`
+}
+
+// NoteResponse - заметка
+type NoteResponse struct {
+	ID        string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Title     string `json:"title" example:"Список покупок"`
+	Content   string `json:"content" example:"Молоко, хлеб, яйца"`
+	CreatedAt int64  `json:"created_at" example:"1700000000"`
+	UpdatedAt int64  `json:"updated_at" example:"1700000000"`
+}
+
+// ListNotesResponse - страница заметок
+type ListNotesResponse struct {
+	Items []NoteResponse `json:"items"`
+	Total int32          `json:"total" example:"42"`
+}
+
+// CreateNote обрабатывает POST /api/v1/notes
+// @Summary      Создание заметки
+// @Description  Создаёт заметку текущего пользователя
+// @Tags         notes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body NoteRequest true "Заголовок и содержимое заметки"
+// @Success      201 {object} NoteResponse "Заметка создана"
+// @Failure      400 {object} ErrorResponse "Невалидные данные"
+// @Failure      401 {object} ErrorResponse "Отсутствует или невалидный токен"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/notes [post]
+func (h *NotesHandler) CreateNote(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req NoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("failed to decode request", "error", err)
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resp, err := h.notesClient.Client.CreateNote(r.Context(), &notesv1.CreateNoteRequest{
+		OwnerId: ownerID,
+		Title:   req.Title,
+		Content: req.Content,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, toNoteResponse(resp.Note))
+}
+
+// ListNotes обрабатывает GET /api/v1/notes
+// @Summary      Список заметок
+// @Description  Возвращает страницу заметок текущего пользователя, новые первыми
+// @Tags         notes
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page query int false "Номер страницы, начиная с 1" default(1)
+// @Param        page_size query int false "Размер страницы, не больше 100" default(20)
+// @Success      200 {object} ListNotesResponse "Страница заметок"
+// @Failure      400 {object} ErrorResponse "Невалидные параметры пагинации"
+// @Failure      401 {object} ErrorResponse "Отсутствует или невалидный токен"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/notes [get]
+func (h *NotesHandler) ListNotes(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	page, err := queryInt32(r, "page")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid page")
+		return
+	}
+	pageSize, err := queryInt32(r, "page_size")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid page_size")
+		return
+	}
+
+	resp, err := h.notesClient.Client.ListNotes(r.Context(), &notesv1.ListNotesRequest{
+		OwnerId:  ownerID,
+		Page:     page,
+		PageSize: pageSize,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	items := make([]NoteResponse, 0, len(resp.Items))
+	for _, note := range resp.Items {
+		items = append(items, toNoteResponse(note))
+	}
+
+	respondJSON(w, http.StatusOK, ListNotesResponse{
+		Items: items,
+		Total: resp.Total,
+	})
+}
+
+// GetNote обрабатывает GET /api/v1/notes/{id}
+// @Summary      Получение заметки
+// @Description  Возвращает заметку текущего пользователя по ID
+// @Tags         notes
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "ID заметки"
+// @Success      200 {object} NoteResponse "Заметка"
+// @Failure      400 {object} ErrorResponse "Невалидный ID"
+// @Failure      401 {object} ErrorResponse "Отсутствует или невалидный токен"
+// @Failure      404 {object} ErrorResponse "Заметка не найдена"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/notes/{id} [get]
+func (h *NotesHandler) GetNote(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	resp, err := h.notesClient.Client.GetNote(r.Context(), &notesv1.GetNoteRequest{
+		Id:      chi.URLParam(r, "id"),
+		OwnerId: ownerID,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toNoteResponse(resp.Note))
+}
+
+// UpdateNote обрабатывает PUT /api/v1/notes/{id}
+// @Summary      Обновление заметки
+// @Description  Заменяет заголовок и содержимое заметки текущего пользователя
+// @Tags         notes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "ID заметки"
+// @Param        request body NoteRequest true "Новые заголовок и содержимое"
+// @Success      200 {object} NoteResponse "Заметка обновлена"
+// @Failure      400 {object} ErrorResponse "Невалидные данные"
+// @Failure      401 {object} ErrorResponse "Отсутствует или невалидный токен"
+// @Failure      404 {object} ErrorResponse "Заметка не найдена"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/notes/{id} [put]
+func (h *NotesHandler) UpdateNote(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req NoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("failed to decode request", "error", err)
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resp, err := h.notesClient.Client.UpdateNote(r.Context(), &notesv1.UpdateNoteRequest{
+		Id:      chi.URLParam(r, "id"),
+		OwnerId: ownerID,
+		Title:   req.Title,
+		Content: req.Content,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toNoteResponse(resp.Note))
+}
+
+// DeleteNote обрабатывает DELETE /api/v1/notes/{id}
+// @Summary      Удаление заметки
+// @Description  Удаляет заметку текущего пользователя
+// @Tags         notes
+// @Security     BearerAuth
+// @Param        id path string true "ID заметки"
+// @Success      204 "Заметка удалена"
+// @Failure      400 {object} ErrorResponse "Невалидный ID"
+// @Failure      401 {object} ErrorResponse "Отсутствует или невалидный токен"
+// @Failure      404 {object} ErrorResponse "Заметка не найдена"
+// @Failure      500 {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /api/v1/notes/{id} [delete]
+func (h *NotesHandler) DeleteNote(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	_, err := h.notesClient.Client.DeleteNote(r.Context(), &notesv1.DeleteNoteRequest{
+		Id:      chi.URLParam(r, "id"),
+		OwnerId: ownerID,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// queryInt32 читает необязательный числовой query параметр; отсутствие - это 0
+func queryInt32(r *http.Request, name string) (int32, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return int32(n), nil
+}
+
+// toNoteResponse конвертирует protobuf заметку в HTTP ответ
+func toNoteResponse(note *notesv1.Note) NoteResponse {
+	return NoteResponse{
+		ID:        note.GetId(),
+		Title:     note.GetTitle(),
+		Content:   note.GetContent(),
+		CreatedAt: note.GetCreateAt(),
+		UpdatedAt: note.GetUpdateAt(),
+	}
+}
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/grpc"
+
+	notesv1 "golang-project/api/proto/gen/go/notes/v1"
+	"golang-project/services/rest-api/internal/client"
+	"golang-project/services/rest-api/internal/middleware"
+)
+
+const (
+	testOwnerID = "user-alice"
+	testToken   = "alice-token"
+	// otherOwnerID владелец, которого клиент пытается подставить в запрос
+	otherOwnerID = "user-mallory"
+)
+
+// notesCall запрос, дошедший до notes-service
+type notesCall struct {
+	method   string
+	id       string
+	ownerID  string
+	page     int32
+	pageSize int32
+}
+
+// stubNotesClient запоминает запросы к notes-service и отвечает успехом
+type stubNotesClient struct {
+	notesv1.NotesServiceClient
+	calls []notesCall
+}
+
+func (c *stubNotesClient) CreateNote(ctx context.Context, in *notesv1.CreateNoteRequest, opts ...grpc.CallOption) (*notesv1.CreateNoteResponse, error) {
+	c.calls = append(c.calls, notesCall{method: "CreateNote", ownerID: in.OwnerId})
+	return &notesv1.CreateNoteResponse{Note: &notesv1.Note{Id: "note-1", Title: in.Title, Content: in.Content}}, nil
+}
+
+func (c *stubNotesClient) GetNote(ctx context.Context, in *notesv1.GetNoteRequest, opts ...grpc.CallOption) (*notesv1.GetNoteResponse, error) {
+	c.calls = append(c.calls, notesCall{method: "GetNote", id: in.Id, ownerID: in.OwnerId})
+	return &notesv1.GetNoteResponse{Note: &notesv1.Note{Id: in.Id}}, nil
+}
+
+func (c *stubNotesClient) ListNotes(ctx context.Context, in *notesv1.ListNotesRequest, opts ...grpc.CallOption) (*notesv1.ListNotesResponse, error) {
+	c.calls = append(c.calls, notesCall{method: "ListNotes", ownerID: in.OwnerId, page: in.Page, pageSize: in.PageSize})
+	return &notesv1.ListNotesResponse{}, nil
+}
+
+func (c *stubNotesClient) UpdateNote(ctx context.Context, in *notesv1.UpdateNoteRequest, opts ...grpc.CallOption) (*notesv1.UpdateNoteResponse, error) {
+	c.calls = append(c.calls, notesCall{method: "UpdateNote", id: in.Id, ownerID: in.OwnerId})
+	return &notesv1.UpdateNoteResponse{Note: &notesv1.Note{Id: in.Id, Title: in.Title, Content: in.Content}}, nil
+}
+
+func (c *stubNotesClient) DeleteNote(ctx context.Context, in *notesv1.DeleteNoteRequest, opts ...grpc.CallOption) (*notesv1.DeleteNoteResponse, error) {
+	c.calls = append(c.calls, notesCall{method: "DeleteNote", id: in.Id, ownerID: in.OwnerId})
+	return &notesv1.DeleteNoteResponse{Ok: true}, nil
+}
+
+// stubValidator принимает только testToken
+type stubValidator struct{}
+
+func (stubValidator) Validate(ctx context.Context, token string) (*middleware.Principal, error) {
+	if token != testToken {
+		return nil, middleware.ErrUnauthenticated
+	}
+	return &middleware.Principal{UserID: testOwnerID}, nil
+}
+
+// newNotesRouter собирает маршруты заметок так же, как cmd/main.go
+func newNotesRouter(stub *stubNotesClient) http.Handler {
+	h := NewNotesHandler(&client.NotesClient{Client: stub})
+
+	r := chi.NewRouter()
+	r.Route("/api/v1/notes", func(r chi.Router) {
+		r.Use(middleware.Auth(stubValidator{}))
+		r.Get("/", h.ListNotes)
+		r.Post("/", h.CreateNote)
+		r.Get("/{id}", h.GetNote)
+		r.Put("/{id}", h.UpdateNote)
+		r.Delete("/{id}", h.DeleteNote)
+	})
+	return r
+}
+
+func TestNotesHandler_OwnerFromToken(t *testing.T) {
+	// Клиент подставляет чужого владельца в query и тело; handler должен его игнорировать
+	const spoofedBody = `{"title":"t","content":"c","owner_id":"` + otherOwnerID + `","ownerId":"` + otherOwnerID + `"}`
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		want       notesCall
+	}{
+		{
+			name:       "create",
+			method:     http.MethodPost,
+			target:     "/api/v1/notes?owner_id=" + otherOwnerID,
+			body:       spoofedBody,
+			wantStatus: http.StatusCreated,
+			want:       notesCall{method: "CreateNote", ownerID: testOwnerID},
+		},
+		{
+			name:       "list",
+			method:     http.MethodGet,
+			target:     "/api/v1/notes?owner_id=" + otherOwnerID,
+			wantStatus: http.StatusOK,
+			want:       notesCall{method: "ListNotes", ownerID: testOwnerID},
+		},
+		{
+			name:       "get",
+			method:     http.MethodGet,
+			target:     "/api/v1/notes/note-1?owner_id=" + otherOwnerID,
+			wantStatus: http.StatusOK,
+			want:       notesCall{method: "GetNote", id: "note-1", ownerID: testOwnerID},
+		},
+		{
+			name:       "update",
+			method:     http.MethodPut,
+			target:     "/api/v1/notes/note-1?owner_id=" + otherOwnerID,
+			body:       spoofedBody,
+			wantStatus: http.StatusOK,
+			want:       notesCall{method: "UpdateNote", id: "note-1", ownerID: testOwnerID},
+		},
+		{
+			name:       "delete",
+			method:     http.MethodDelete,
+			target:     "/api/v1/notes/note-1?owner_id=" + otherOwnerID,
+			wantStatus: http.StatusNoContent,
+			want:       notesCall{method: "DeleteNote", id: "note-1", ownerID: testOwnerID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubNotesClient{}
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+testToken)
+			req.Header.Set("X-User-ID", otherOwnerID)
+			rec := httptest.NewRecorder()
+			newNotesRouter(stub).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if len(stub.calls) != 1 || stub.calls[0] != tt.want {
+				t.Errorf("notes-service calls = %+v, want [%+v]", stub.calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotesHandler_RequiresToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{name: "no token"},
+		{name: "unknown token", header: "Bearer " + otherOwnerID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubNotesClient{}
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/notes", strings.NewReader(`{"title":"t","content":"c"}`))
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			newNotesRouter(stub).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+			}
+			if len(stub.calls) != 0 {
+				t.Errorf("notes-service calls = %+v, want none", stub.calls)
+			}
+		})
+	}
+}
+
+func TestNotesHandler_ListNotes_Pagination(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		wantStatus   int
+		wantPage     int32
+		wantPageSize int32
+	}{
+		{name: "defaults", query: "", wantStatus: http.StatusOK},
+		{name: "explicit", query: "?page=3&page_size=50", wantStatus: http.StatusOK, wantPage: 3, wantPageSize: 50},
+		{name: "max int32", query: "?page=2147483647", wantStatus: http.StatusOK, wantPage: 2147483647},
+		{name: "page not a number", query: "?page=abc", wantStatus: http.StatusBadRequest},
+		{name: "page fraction", query: "?page=1.5", wantStatus: http.StatusBadRequest},
+		{name: "page overflows int32", query: "?page=2147483648", wantStatus: http.StatusBadRequest},
+		{name: "page underflows int32", query: "?page=-2147483649", wantStatus: http.StatusBadRequest},
+		{name: "page_size not a number", query: "?page_size=ten", wantStatus: http.StatusBadRequest},
+		{name: "page_size overflows int32", query: "?page_size=99999999999", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubNotesClient{}
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/notes"+tt.query, nil)
+			req.Header.Set("Authorization", "Bearer "+testToken)
+			rec := httptest.NewRecorder()
+			newNotesRouter(stub).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				// Невалидные параметры не доходят до notes-service
+				if len(stub.calls) != 0 {
+					t.Errorf("notes-service calls = %+v, want none", stub.calls)
+				}
+				return
+			}
+			want := notesCall{method: "ListNotes", ownerID: testOwnerID, page: tt.wantPage, pageSize: tt.wantPageSize}
+			if len(stub.calls) != 1 || stub.calls[0] != want {
+				t.Errorf("notes-service calls = %+v, want [%+v]", stub.calls, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authv1 "golang-project/api/proto/gen/go/auth/v1"
+	"golang-project/pkg/auth/jwt"
+)
+
+// ErrUnauthenticated возвращается валидатором, если токен недействителен
+var ErrUnauthenticated = errors.New("invalid or expired token")
+
+// Principal аутентифицированный пользователь запроса
+type Principal struct {
+	UserID string
+	Roles  []string
+	Scopes []string
+}
+
+// HasRole проверяет, есть ли у пользователя роль
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenValidator проверяет access токен и возвращает его владельца
+type TokenValidator interface {
+	Validate(ctx context.Context, token string) (*Principal, error)
+}
+
+// LocalValidator проверяет подпись и claims токена локально по публичному ключу.
+// Отзыв он не видит: токены после SignOut, сброса пароля или удаления аккаунта
+// принимаются до истечения срока, поэтому по умолчанию используется RemoteValidator.
+type LocalValidator struct {
+	jwt  *jwt.Manager
+	opts []jwt.ValidateOption
+}
+
+// NewLocalValidator создаёт валидатор поверх verify-only jwt.Manager
+func NewLocalValidator(jwtManager *jwt.Manager, opts ...jwt.ValidateOption) *LocalValidator {
+	return &LocalValidator{jwt: jwtManager, opts: opts}
+}
+
+func (v *LocalValidator) Validate(ctx context.Context, token string) (*Principal, error) {
+	claims, err := v.jwt.Validate(token, v.opts...)
+	if err != nil {
+		if errors.Is(err, jwt.ErrExpiredToken) || errors.Is(err, jwt.ErrInvalidToken) {
+			return nil, ErrUnauthenticated
+		}
+		return nil, err
+	}
+	return &Principal{
+		UserID: claims.UserID,
+		Roles:  claims.Roles,
+		Scopes: claims.Scopes,
+	}, nil
+}
+
+// RemoteValidator проверяет токен через AuthService.ValidateToken,
+// включая denylist и отзыв всех токенов пользователя
+type RemoteValidator struct {
+	client   authv1.AuthServiceClient
+	audience string
+}
+
+// NewRemoteValidator создаёт валидатор поверх gRPC клиента auth-service.
+// Если audience не пуст, auth-service требует его в claim aud.
+func NewRemoteValidator(client authv1.AuthServiceClient, audience string) *RemoteValidator {
+	return &RemoteValidator{client: client, audience: audience}
+}
+
+func (v *RemoteValidator) Validate(ctx context.Context, token string) (*Principal, error) {
+	resp, err := v.client.ValidateToken(ctx, &authv1.ValidateTokenRequest{
+		Token:    token,
+		Audience: v.audience,
+	})
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, ErrUnauthenticated
+		}
+		return nil, err
+	}
+	if !resp.Valid {
+		return nil, ErrUnauthenticated
+	}
+	return &Principal{
+		UserID: resp.UserId,
+		Roles:  resp.Roles,
+		Scopes: resp.Scopes,
+	}, nil
+}
+
+type contextKey struct{}
+
+// PrincipalFromContext возвращает пользователя, положенного middleware Auth
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(contextKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// UserIDFromContext возвращает ID пользователя, положенный middleware Auth
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.UserID == "" {
+		return "", false
+	}
+	return principal.UserID, true
+}
+
+// Auth - middleware, пропускающий только запросы с валидным Bearer токеном.
+// Пользователь доступен обработчикам через PrincipalFromContext и UserIDFromContext.
+func Auth(v TokenValidator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := BearerToken(r)
+			if !ok {
+				unauthorized(w, "missing authorization header")
+				return
+			}
+
+			principal, err := v.Validate(r.Context(), token)
+			if err != nil {
+				if errors.Is(err, ErrUnauthenticated) {
+					unauthorized(w, "invalid or expired token")
+					return
+				}
+				slog.Error("failed to validate token", "error", err)
+				writeError(w, http.StatusInternalServerError, "internal server error")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), contextKey{}, principal)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireRole - middleware, пропускающий только пользователей с ролью role.
+// Ставится после Auth; роли берутся из токена, поэтому выданная или отозванная
+// роль начинает действовать после обновления access токена.
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := PrincipalFromContext(r.Context())
+			if !ok {
+				unauthorized(w, "missing authorization header")
+				return
+			}
+			if !principal.HasRole(role) {
+				writeError(w, http.StatusForbidden, "insufficient permissions")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// BearerToken извлекает токен из заголовка "Authorization: Bearer <token>"
+func BearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func unauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer`)
+	writeError(w, http.StatusUnauthorized, message)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
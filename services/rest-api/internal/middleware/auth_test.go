@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type fakeValidator struct {
+	tokens map[string]string
+	err    error
+}
+
+func (v *fakeValidator) Validate(ctx context.Context, token string) (*Principal, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	userID, ok := v.tokens[token]
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	return &Principal{UserID: userID}, nil
+}
+
+func TestAuth(t *testing.T) {
+	validator := &fakeValidator{tokens: map[string]string{"good": "user-123"}}
+
+	tests := []struct {
+		name       string
+		validator  TokenValidator
+		header     string
+		wantStatus int
+		wantUserID string
+	}{
+		{
+			name:       "valid token",
+			validator:  validator,
+			header:     "Bearer good",
+			wantStatus: http.StatusOK,
+			wantUserID: "user-123",
+		},
+		{
+			name:       "lowercase scheme",
+			validator:  validator,
+			header:     "bearer good",
+			wantStatus: http.StatusOK,
+			wantUserID: "user-123",
+		},
+		{
+			name:       "missing header",
+			validator:  validator,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "token without scheme",
+			validator:  validator,
+			header:     "good",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid token",
+			validator:  validator,
+			header:     "Bearer bad",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "validator failure",
+			validator:  &fakeValidator{err: errors.New("auth service unavailable")},
+			header:     "Bearer good",
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUserID string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotUserID, _ = UserIDFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+
+			Auth(tt.validator)(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if gotUserID != tt.wantUserID {
+				t.Errorf("UserIDFromContext() = %q, want %q", gotUserID, tt.wantUserID)
+			}
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		principal  *Principal
+		wantStatus int
+	}{
+		{
+			name:       "has role",
+			principal:  &Principal{UserID: "user-123", Roles: []string{"admin"}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing role",
+			principal:  &Principal{UserID: "user-123", Roles: []string{"editor"}},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "not authenticated",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.principal != nil {
+				req = req.WithContext(context.WithValue(req.Context(), contextKey{}, tt.principal))
+			}
+			rec := httptest.NewRecorder()
+
+			RequireRole("admin")(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRealIP(t *testing.T) {
+	trusted, err := ParseTrustedProxies("10.0.0.0/8, 192.0.2.10")
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        string
+		xRealIP    string
+		want       string
+	}{
+		{
+			name:       "direct client spoofs x-forwarded-for",
+			remoteAddr: "203.0.113.5:41000",
+			xff:        "198.51.100.1",
+			want:       "203.0.113.5:41000",
+		},
+		{
+			name:       "direct client spoofs x-real-ip",
+			remoteAddr: "203.0.113.5:41000",
+			xRealIP:    "198.51.100.1",
+			want:       "203.0.113.5:41000",
+		},
+		{
+			name:       "trusted proxy",
+			remoteAddr: "10.1.2.3:41000",
+			xff:        "203.0.113.5",
+			want:       "203.0.113.5",
+		},
+		{
+			name:       "trusted single address",
+			remoteAddr: "192.0.2.10:41000",
+			xRealIP:    "203.0.113.5",
+			want:       "203.0.113.5",
+		},
+		{
+			name:       "client prepends fake hop",
+			remoteAddr: "10.1.2.3:41000",
+			xff:        "198.51.100.1, 203.0.113.5",
+			want:       "203.0.113.5",
+		},
+		{
+			name:       "chain of trusted proxies",
+			remoteAddr: "10.1.2.3:41000",
+			xff:        "203.0.113.5, 10.9.9.9",
+			want:       "203.0.113.5",
+		},
+		{
+			name:       "garbage header from trusted proxy",
+			remoteAddr: "10.1.2.3:41000",
+			xff:        "not-an-ip",
+			want:       "10.1.2.3:41000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := RealIP(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.RemoteAddr
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.xff != "" {
+				req.Header.Set("X-Forwarded-For", tt.xff)
+			}
+			if tt.xRealIP != "" {
+				req.Header.Set("X-Real-IP", tt.xRealIP)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("RemoteAddr = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	if nets, err := ParseTrustedProxies(""); err != nil || len(nets) != 0 {
+		t.Errorf("ParseTrustedProxies(\"\") = %v, %v, want no proxies", nets, err)
+	}
+	if _, err := ParseTrustedProxies("10.0.0.0/33"); err == nil {
+		t.Error("ParseTrustedProxies() error = nil for invalid CIDR")
+	}
+	if _, err := ParseTrustedProxies("proxy.local"); err == nil {
+		t.Error("ParseTrustedProxies() error = nil for hostname")
+	}
+}